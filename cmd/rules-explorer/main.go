@@ -1,13 +1,38 @@
 package main
 
 import (
+	"flag"
+	"fmt"
 	"log"
+	"os"
 
 	"rules-explorer/internal/app"
+	"rules-explorer/internal/cli"
 )
 
 func main() {
-	application := app.New()
+	if len(os.Args) > 1 {
+		if command, ok := cli.Lookup(os.Args[1]); ok {
+			os.Exit(command.Execute(os.Args[2:], os.Stdout, os.Stderr))
+		}
+	}
+	
+	config := app.NewConfig()
+	flag.StringVar(&config.ConfigPath, "config", "", "path to a rules-explorer config file")
+	flag.BoolVar(&config.NoIgnore, "no-ignore", false, "don't respect .gitignore files or skip heavy directories")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: rules-explorer [flags]\n       rules-explorer <command> [flags] [args]\n\n")
+		cli.PrintCommands(os.Stderr)
+		fmt.Fprintf(os.Stderr, "\nFlags:\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	
+	if err := config.Load(); err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+	
+	application := app.New(config)
 	
 	if err := application.Initialize(); err != nil {
 		log.Fatalf("Failed to initialize app: %v", err)
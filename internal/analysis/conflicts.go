@@ -0,0 +1,319 @@
+package analysis
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+
+	"rules-explorer/internal/core/types"
+)
+
+// Polarity is whether a statement asks for something or forbids it
+type Polarity int
+
+const (
+	Require Polarity = iota
+	Forbid
+)
+
+func (p Polarity) String() string {
+	if p == Forbid {
+		return "forbid"
+	}
+	return "require"
+}
+
+// Statement is an imperative found in a file, like "always X" or "never Y"
+type Statement struct {
+	File types.FileItem
+	Line int
+	// Text is the sentence the statement was found in
+	Text     string
+	Polarity Polarity
+	// Trigger is the phrase that made it a statement ("never", "instead of")
+	Trigger string
+	// Subject is what is required or forbidden, as normalized words
+	Subject []string
+}
+
+// Location is path:line
+func (s Statement) Location() string {
+	return Paragraph{File: s.File, Line: s.Line}.Location()
+}
+
+// Conflict is a pair of statements from files that apply to the same paths,
+// where one requires what the other forbids
+type Conflict struct {
+	Require Statement
+	Forbid  Statement
+}
+
+// Topic is the subject the two statements share
+func (c Conflict) Topic() string {
+	a, b := c.Require.Subject, c.Forbid.Subject
+	if len(b) < len(a) {
+		a = b
+	}
+	return strings.Join(a, " ")
+}
+
+var (
+	// "use X instead of Y", "prefer X over Y"
+	insteadPattern = regexp.MustCompile(`\b(?:use|prefer|choose|pick|write)\s+(.+?)\s+(instead of|rather than|over)\s+(.+)`)
+	forbidPattern  = regexp.MustCompile(`\b(never|do not|don't|dont|must not|mustn't|should not|shouldn't|avoid|stop)\s+(.+)`)
+	requirePattern = regexp.MustCompile(`\b(always|must|make sure to|make sure you|ensure you)\s+(.+)`)
+	// An imperative at the start of a sentence: "Use X", "Prefer X"
+	leadingPattern = regexp.MustCompile(`^(use|prefer)\s+(.+)`)
+
+	// A verb that negates its object, so "never skip tests" asks for tests
+	negatingVerb = regexp.MustCompile(`^(skip(?:ping)?|ignor(?:e|ing)|disabl(?:e|ing)|remov(?:e|ing)|omit(?:ting)?|drop(?:ping)?|bypass(?:ing)?|delet(?:e|ing)|leav(?:e|ing) out|forget(?:ting)?(?: to)?)\s+`)
+
+	// A subject ends where a condition or reason starts
+	subjectEnd = regexp.MustCompile(`[,:;(]|\s(?:when|if|unless|because|since|so that|except|but|for example|e\.g\.|i\.e\.)\s`)
+)
+
+// Statements extracts the imperatives from a file
+func Statements(file types.FileItem) []Statement {
+	statements := make([]Statement, 0)
+	for _, paragraph := range Paragraphs(file) {
+		if strings.HasPrefix(strings.TrimSpace(paragraph.Text), "```") ||
+			strings.HasPrefix(strings.TrimSpace(paragraph.Text), "~~~") {
+			continue
+		}
+		for _, sentence := range sentences(paragraph) {
+			statements = append(statements, parseStatements(file, sentence)...)
+		}
+	}
+	return statements
+}
+
+type sentence struct {
+	line int
+	text string
+}
+
+// sentences splits a paragraph at sentence ends and list items
+func sentences(paragraph Paragraph) []sentence {
+	result := make([]sentence, 0)
+	lines := strings.Split(paragraph.Text, "\n")
+
+	var current strings.Builder
+	start := paragraph.Line
+	flush := func() {
+		if text := strings.TrimSpace(current.String()); text != "" {
+			result = append(result, sentence{line: start, text: text})
+		}
+		current.Reset()
+	}
+
+	for i, line := range lines {
+		lineNo := paragraph.Line + i
+		trimmed := strings.TrimSpace(line)
+		if item := listItem.FindString(trimmed); item != "" {
+			flush()
+			trimmed = trimmed[len(item):]
+		}
+		if current.Len() == 0 {
+			start = lineNo
+		} else {
+			current.WriteByte(' ')
+		}
+
+		for {
+			end := sentenceEnd.FindStringIndex(trimmed)
+			if end == nil {
+				current.WriteString(trimmed)
+				break
+			}
+			current.WriteString(trimmed[:end[0]+1])
+			flush()
+			start = lineNo
+			trimmed = trimmed[end[1]:]
+		}
+	}
+	flush()
+	return result
+}
+
+var (
+	listItem    = regexp.MustCompile(`^(?:[-*+]|\d+[.)])\s+`)
+	sentenceEnd = regexp.MustCompile(`[.!?;]\s+`)
+	// Emphasis and code markers are dropped before matching
+	markup = strings.NewReplacer("**", "", "__", "", "`", "", "*", "", "_", " ")
+)
+
+func parseStatements(file types.FileItem, s sentence) []Statement {
+	text := strings.ToLower(markup.Replace(s.text))
+	statement := func(polarity Polarity, trigger, subject string) (Statement, bool) {
+		if m := negatingVerb.FindStringSubmatch(subject); m != nil {
+			if polarity == Require {
+				polarity = Forbid
+			} else {
+				polarity = Require
+			}
+			trigger += " " + m[1]
+			subject = subject[len(m[0]):]
+		}
+		words := subjectWords(subject)
+		if len(words) == 0 {
+			return Statement{}, false
+		}
+		return Statement{
+			File:     file,
+			Line:     s.line,
+			Text:     s.text,
+			Polarity: polarity,
+			Trigger:  trigger,
+			Subject:  words,
+		}, true
+	}
+
+	result := make([]Statement, 0, 2)
+	add := func(polarity Polarity, trigger, subject string) {
+		if st, ok := statement(polarity, trigger, subject); ok {
+			result = append(result, st)
+		}
+	}
+
+	if m := insteadPattern.FindStringSubmatch(text); m != nil {
+		add(Require, m[2], m[1])
+		add(Forbid, m[2], m[3])
+		return result
+	}
+	if m := forbidPattern.FindStringSubmatch(text); m != nil {
+		add(Forbid, m[1], m[2])
+		return result
+	}
+	if m := requirePattern.FindStringSubmatch(text); m != nil {
+		add(Require, m[1], m[2])
+		return result
+	}
+	if m := leadingPattern.FindStringSubmatch(text); m != nil {
+		add(Require, m[1], m[2])
+	}
+	return result
+}
+
+// Words that say nothing about what is required: articles, pronouns and the
+// verbs imperatives are usually built with
+var fillerWords = map[string]bool{
+	"a": true, "an": true, "the": true, "to": true, "of": true, "in": true, "on": true,
+	"for": true, "with": true, "and": true, "or": true, "any": true, "all": true,
+	"your": true, "you": true, "our": true, "we": true, "it": true, "its": true,
+	"this": true, "that": true, "these": true, "those": true, "be": true, "is": true,
+	"are": true, "use": true, "using": true, "used": true, "prefer": true, "do": true,
+	"make": true, "write": true, "add": true, "keep": true, "put": true, "have": true,
+	"always": true, "never": true, "ever": true, "please": true, "also": true,
+	"instead": true, "only": true, "at": true, "by": true, "as": true,
+}
+
+// subjectWords normalizes the start of subject up to the first condition
+func subjectWords(subject string) []string {
+	if loc := subjectEnd.FindStringIndex(" " + subject + " "); loc != nil {
+		subject = (" " + subject)[:loc[0]]
+	}
+	words := make([]string, 0)
+	for _, w := range strings.FieldsFunc(subject, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z') && !(r >= '0' && r <= '9') && r != '.' && r != '-' && r != '/'
+	}) {
+		w = strings.Trim(w, ".-")
+		if w == "" || fillerWords[w] {
+			continue
+		}
+		words = append(words, stem(w))
+		if len(words) == 6 {
+			break
+		}
+	}
+	return words
+}
+
+// stem folds plurals so "semicolons" and "semicolon" match
+func stem(w string) string {
+	switch {
+	case len(w) > 4 && strings.HasSuffix(w, "ies"):
+		return w[:len(w)-3] + "y"
+	case len(w) > 3 && strings.HasSuffix(w, "s") && !strings.HasSuffix(w, "ss"):
+		return w[:len(w)-1]
+	}
+	return w
+}
+
+// opposed reports whether two subjects are about the same thing: every word
+// of the shorter one appears in the longer one, and they share at least
+// half of their words
+func opposed(a, b []string) bool {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	set := make(map[string]bool, len(b))
+	for _, w := range b {
+		set[w] = true
+	}
+	for _, w := range a {
+		if !set[w] {
+			return false
+		}
+	}
+	return 2*len(a) >= len(b)
+}
+
+// FindConflicts pairs statements from different files that apply to
+// overlapping paths where one requires what the other forbids
+func FindConflicts(ctx context.Context, files []types.FileItem) ([]Conflict, error) {
+	type located struct {
+		Statement
+		reach reach
+	}
+
+	requires := make([]located, 0)
+	forbidsByWord := make(map[string][]located)
+	for _, file := range files {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		r := reachOf(file)
+		for _, st := range Statements(file) {
+			if st.Polarity == Require {
+				requires = append(requires, located{st, r})
+			} else {
+				for _, w := range st.Subject {
+					forbidsByWord[w] = append(forbidsByWord[w], located{st, r})
+				}
+			}
+		}
+	}
+
+	conflicts := make([]Conflict, 0)
+	seen := make(map[string]bool)
+	for i, req := range requires {
+		if i%64 == 0 && ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		for _, w := range req.Subject {
+			for _, forbid := range forbidsByWord[w] {
+				if forbid.File.Path == req.File.Path || !opposed(req.Subject, forbid.Subject) ||
+					!req.reach.overlaps(forbid.reach) {
+					continue
+				}
+				conflict := Conflict{Require: req.Statement, Forbid: forbid.Statement}
+				key := req.File.Path + "\x00" + forbid.File.Path + "\x00" + conflict.Topic()
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				conflicts = append(conflicts, conflict)
+			}
+		}
+	}
+
+	sort.SliceStable(conflicts, func(i, j int) bool {
+		a, b := conflicts[i].Require, conflicts[j].Require
+		if a.File.Path != b.File.Path {
+			return a.File.Path < b.File.Path
+		}
+		return a.Line < b.Line
+	})
+	return conflicts, nil
+}
@@ -0,0 +1,41 @@
+package analysis
+
+import (
+	"context"
+	"testing"
+
+	"rules-explorer/internal/core/types"
+)
+
+func TestFindConflicts(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want int
+	}{
+		{"always and never", "Always use semicolons.", "Never use semicolons.", 1},
+		{"instead of", "Use tabs instead of spaces.", "Always use spaces.", 1},
+		{"plural", "Always add type annotations.", "Avoid type annotation.", 1},
+		{"different subjects", "Always use semicolons.", "Never use default exports.", 0},
+		{"negated forbid agrees", "Always test.", "Never skip tests.", 0},
+		{"negated require disagrees", "Always skip tests.", "Never skip tests.", 1},
+		{"negated forbid disagrees", "Don't ignore lint warnings.", "Always ignore lint warnings.", 1},
+		{"negated forbid against forbid", "Avoid mocks.", "Never remove mocks.", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files := []types.FileItem{
+				{Path: "CLAUDE.md", Content: tt.a},
+				{Path: "AGENTS.md", Content: tt.b},
+			}
+			conflicts, err := FindConflicts(context.Background(), files)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(conflicts) != tt.want {
+				t.Errorf("got %d conflicts, want %d: %+v", len(conflicts), tt.want, conflicts)
+			}
+		})
+	}
+}
@@ -0,0 +1,311 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+
+	"rules-explorer/internal/core/types"
+)
+
+// DupeOptions tune duplicate detection
+type DupeOptions struct {
+	// Threshold is the Jaccard similarity of word shingles at which two
+	// paragraphs count as near-duplicates, between 0 and 1
+	Threshold float64
+	// MinWords skips paragraphs too short to be worth reporting
+	MinWords int
+}
+
+var DefaultDupeOptions = DupeOptions{Threshold: 0.5, MinWords: 8}
+
+// Cluster is a group of paragraphs that are copies of each other
+type Cluster struct {
+	Paragraphs []Paragraph
+	// Exact is set when every copy is identical after normalizing case,
+	// whitespace and punctuation
+	Exact bool
+	// Similarity is the lowest similarity between two copies that were
+	// joined; 1 for exact clusters
+	Similarity float64
+}
+
+// Files returns how many different files the cluster spans
+func (c Cluster) Files() int {
+	paths := make(map[string]bool)
+	for _, p := range c.Paragraphs {
+		paths[p.File.Path] = true
+	}
+	return len(paths)
+}
+
+// Summary describes a cluster as "3 copies in 2 files, exact"
+func (c Cluster) Summary() string {
+	kind := "exact"
+	if !c.Exact {
+		kind = fmt.Sprintf("%.0f%% similar", c.Similarity*100)
+	}
+	return fmt.Sprintf("%d copies in %d files, %s", len(c.Paragraphs), c.Files(), kind)
+}
+
+const (
+	shingleSize = 3
+	// 32 bands of 2 rows find most pairs down to a similarity of about 0.4;
+	// every candidate is verified against the real shingle sets
+	minhashBands = 32
+	minhashRows  = 2
+	minhashSize  = minhashBands * minhashRows
+)
+
+type shingled struct {
+	paragraph Paragraph
+	key       string
+	shingles  []uint64
+}
+
+// FindDuplicates groups paragraphs across files that are exact or near
+// copies. Clusters spanning the most files come first.
+func FindDuplicates(ctx context.Context, files []types.FileItem, opts DupeOptions) ([]Cluster, error) {
+	if opts.Threshold <= 0 || opts.Threshold > 1 {
+		opts.Threshold = DefaultDupeOptions.Threshold
+	}
+	if opts.MinWords <= 0 {
+		opts.MinWords = DefaultDupeOptions.MinWords
+	}
+
+	items := make([]shingled, 0)
+	for _, file := range files {
+		for _, p := range Paragraphs(file) {
+			ws := words(p.Text)
+			if len(ws) < opts.MinWords {
+				continue
+			}
+			items = append(items, shingled{paragraph: p, key: strings.Join(ws, " "), shingles: shingles(ws)})
+		}
+	}
+
+	sets := newUnionFind(len(items))
+	similarity := make([]float64, len(items))
+	for i := range similarity {
+		similarity[i] = 1
+	}
+	join := func(a, b int, sim float64) {
+		ra, rb := sets.find(a), sets.find(b)
+		low := min(similarity[ra], similarity[rb], sim)
+		if ra != rb {
+			sets.union(ra, rb)
+		}
+		similarity[sets.find(a)] = low
+	}
+
+	// Exact copies share a key
+	byKey := make(map[string]int)
+	for i, item := range items {
+		if first, ok := byKey[item.key]; ok {
+			join(first, i, 1)
+		} else {
+			byKey[item.key] = i
+		}
+	}
+
+	// Near copies share a MinHash band; each distinct key is hashed once
+	buckets := make(map[[2]uint64][]int)
+	for i, item := range items {
+		if i%64 == 0 && ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if byKey[item.key] != i {
+			continue
+		}
+		signature := minhash(item.shingles)
+		for band := 0; band < minhashBands; band++ {
+			h := uint64(band) + 1
+			for _, v := range signature[band*minhashRows : (band+1)*minhashRows] {
+				h = mix(h ^ v)
+			}
+			bucket := [2]uint64{uint64(band), h}
+			buckets[bucket] = append(buckets[bucket], i)
+		}
+	}
+
+	checked := make(map[[2]int]bool)
+	for _, bucket := range buckets {
+		for x := 0; x < len(bucket); x++ {
+			for y := x + 1; y < len(bucket); y++ {
+				a, b := bucket[x], bucket[y]
+				if checked[[2]int{a, b}] {
+					continue
+				}
+				checked[[2]int{a, b}] = true
+				if sets.find(a) == sets.find(b) {
+					continue
+				}
+				if sim := jaccard(items[a].shingles, items[b].shingles); sim >= opts.Threshold {
+					join(a, b, sim)
+				}
+			}
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+	}
+
+	groups := make(map[int][]int)
+	for i := range items {
+		root := sets.find(i)
+		groups[root] = append(groups[root], i)
+	}
+
+	clusters := make([]Cluster, 0)
+	for root, members := range groups {
+		if len(members) < 2 {
+			continue
+		}
+		cluster := Cluster{Exact: true, Similarity: similarity[root]}
+		for _, i := range members {
+			cluster.Paragraphs = append(cluster.Paragraphs, items[i].paragraph)
+			if items[i].key != items[members[0]].key {
+				cluster.Exact = false
+			}
+		}
+		if cluster.Exact {
+			cluster.Similarity = 1
+		}
+		sort.Slice(cluster.Paragraphs, func(i, j int) bool {
+			a, b := cluster.Paragraphs[i], cluster.Paragraphs[j]
+			if a.File.Path != b.File.Path {
+				return a.File.Path < b.File.Path
+			}
+			return a.Line < b.Line
+		})
+		clusters = append(clusters, cluster)
+	}
+
+	sort.Slice(clusters, func(i, j int) bool {
+		a, b := clusters[i], clusters[j]
+		if a.Files() != b.Files() {
+			return a.Files() > b.Files()
+		}
+		if len(a.Paragraphs) != len(b.Paragraphs) {
+			return len(a.Paragraphs) > len(b.Paragraphs)
+		}
+		if a.Similarity != b.Similarity {
+			return a.Similarity > b.Similarity
+		}
+		first, second := a.Paragraphs[0], b.Paragraphs[0]
+		if first.File.Path != second.File.Path {
+			return first.File.Path < second.File.Path
+		}
+		return first.Line < second.Line
+	})
+
+	return clusters, nil
+}
+
+// shingles hashes every run of shingleSize words, sorted and deduplicated
+func shingles(ws []string) []uint64 {
+	n := max(len(ws)-shingleSize+1, 1)
+	set := make([]uint64, 0, n)
+	for i := 0; i < n; i++ {
+		h := fnv.New64a()
+		for _, w := range ws[i:min(i+shingleSize, len(ws))] {
+			h.Write([]byte(w))
+			h.Write([]byte{0})
+		}
+		set = append(set, h.Sum64())
+	}
+
+	sort.Slice(set, func(i, j int) bool { return set[i] < set[j] })
+	unique := set[:0]
+	for i, v := range set {
+		if i == 0 || v != set[i-1] {
+			unique = append(unique, v)
+		}
+	}
+	return unique
+}
+
+// minhash keeps the smallest value of each of minhashSize hash functions
+// over the shingles
+func minhash(shingles []uint64) [minhashSize]uint64 {
+	var signature [minhashSize]uint64
+	for i := range signature {
+		signature[i] = ^uint64(0)
+	}
+	for _, s := range shingles {
+		for i := range signature {
+			if h := mix(s ^ seeds[i]); h < signature[i] {
+				signature[i] = h
+			}
+		}
+	}
+	return signature
+}
+
+var seeds = func() [minhashSize]uint64 {
+	var s [minhashSize]uint64
+	state := uint64(0x9e3779b97f4a7c15)
+	for i := range s {
+		state = mix(state + uint64(i))
+		s[i] = state
+	}
+	return s
+}()
+
+// mix is the splitmix64 finalizer
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// jaccard compares two sorted sets
+func jaccard(a, b []uint64) float64 {
+	shared := 0
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] == b[j]:
+			shared++
+			i++
+			j++
+		case a[i] < b[j]:
+			i++
+		default:
+			j++
+		}
+	}
+	union := len(a) + len(b) - shared
+	if union == 0 {
+		return 0
+	}
+	return float64(shared) / float64(union)
+}
+
+type unionFind struct {
+	parent []int
+}
+
+func newUnionFind(n int) *unionFind {
+	parent := make([]int, n)
+	for i := range parent {
+		parent[i] = i
+	}
+	return &unionFind{parent: parent}
+}
+
+func (u *unionFind) find(x int) int {
+	for u.parent[x] != x {
+		u.parent[x] = u.parent[u.parent[x]]
+		x = u.parent[x]
+	}
+	return x
+}
+
+func (u *unionFind) union(a, b int) {
+	u.parent[u.find(a)] = u.find(b)
+}
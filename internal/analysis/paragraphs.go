@@ -0,0 +1,102 @@
+// Package analysis looks across all discovered files for guidance that is
+// repeated or drifting apart.
+package analysis
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"rules-explorer/internal/core/types"
+)
+
+// Paragraph is a block of prose or a code block from a file. Line and EndLine
+// are 1-based and inclusive; Start and End are byte offsets into the content.
+type Paragraph struct {
+	File    types.FileItem
+	Line    int
+	EndLine int
+	Start   int
+	End     int
+	Text    string
+}
+
+// Location is path:line or path:line-endLine
+func (p Paragraph) Location() string {
+	if p.EndLine > p.Line {
+		return fmt.Sprintf("%s:%d-%d", p.File.Path, p.Line, p.EndLine)
+	}
+	return fmt.Sprintf("%s:%d", p.File.Path, p.Line)
+}
+
+// Paragraphs splits the body of file into paragraphs at blank lines and
+// headings. Frontmatter and headings themselves are skipped; a fenced code
+// block is kept whole.
+func Paragraphs(file types.FileItem) []Paragraph {
+	paragraphs := make([]Paragraph, 0)
+	content := file.Content
+
+	startLine := 1
+	if file.Metadata != nil && file.Metadata.BodyLine > 0 {
+		startLine = file.Metadata.BodyLine
+	}
+
+	var current *Paragraph
+	flush := func() {
+		if current != nil {
+			current.Text = content[current.Start:current.End]
+			paragraphs = append(paragraphs, *current)
+			current = nil
+		}
+	}
+
+	inFence := false
+	fence := ""
+	offset := 0
+	for i, line := range strings.SplitAfter(content, "\n") {
+		lineNo := i + 1
+		start := offset
+		offset += len(line)
+		if lineNo < startLine {
+			continue
+		}
+
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			marker := trimmed[:3]
+			if !inFence {
+				inFence, fence = true, marker
+			} else if marker == fence {
+				inFence = false
+			}
+		} else if !inFence && (trimmed == "" || strings.HasPrefix(trimmed, "#")) {
+			flush()
+			continue
+		}
+
+		if current == nil {
+			current = &Paragraph{File: file, Line: lineNo, Start: start}
+		}
+		current.EndLine = lineNo
+		current.End = start + len(strings.TrimRight(line, "\r\n"))
+	}
+	flush()
+
+	return paragraphs
+}
+
+// words lowercases text and splits it into words, dropping Markdown
+// punctuation so that reformatting doesn't hide a copy
+func words(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '.' && r != '/' && r != '-'
+	})
+	ws := fields[:0]
+	for _, w := range fields {
+		// Keep paths and versions, but not sentence punctuation
+		if w = strings.Trim(w, ".-"); w != "" {
+			ws = append(ws, w)
+		}
+	}
+	return ws
+}
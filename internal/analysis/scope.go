@@ -0,0 +1,111 @@
+package analysis
+
+import (
+	"path"
+	"strings"
+
+	"rules-explorer/internal/core/frontmatter"
+	"rules-explorer/internal/core/glob"
+	"rules-explorer/internal/core/resolver"
+	"rules-explorer/internal/core/types"
+)
+
+// reach is where a file's instructions apply: every file below dir, or only
+// the ones matching globs. Globs are relative to the project root.
+type reach struct {
+	dir   string
+	globs []string
+}
+
+// reachOf works out a file's reach from its location and frontmatter. A
+// file inside a tool directory (.cursor/rules, .claude, .github) applies to
+// the directory holding that tool directory.
+func reachOf(file types.FileItem) reach {
+	var r reach
+	if scope, ok := resolver.Scope(file); ok {
+		r.dir = scope
+	} else {
+		r.dir = resolver.Clean(path.Dir(file.Path))
+		segments := strings.Split(r.dir, "/")
+		for i, segment := range segments {
+			if strings.HasPrefix(segment, ".") {
+				r.dir = strings.Join(segments[:i], "/")
+				break
+			}
+		}
+	}
+
+	m := file.Metadata
+	if m == nil || m.AlwaysApply {
+		return r
+	}
+	globs := m.Globs
+	if applyTo := m.Fields["applyTo"]; len(globs) == 0 && applyTo != "" {
+		// Copilot's path-specific instructions
+		globs = frontmatter.SplitList(applyTo)
+	}
+	for _, g := range globs {
+		g = strings.TrimPrefix(strings.TrimPrefix(g, "./"), "/")
+		if !strings.Contains(g, "/") {
+			g = "**/" + g
+		}
+		if r.dir != "" {
+			g = r.dir + "/" + g
+		}
+		r.globs = append(r.globs, g)
+	}
+	return r
+}
+
+func (r reach) overlaps(other reach) bool {
+	if !resolver.InScope(r.dir, other.dir) && !resolver.InScope(other.dir, r.dir) {
+		return false
+	}
+	if len(r.globs) == 0 || len(other.globs) == 0 {
+		return true
+	}
+	for _, a := range r.globs {
+		for _, b := range other.globs {
+			if glob.Match(a, samplePath(b)) || glob.Match(b, samplePath(a)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// samplePath builds a path the glob matches, to test whether another glob
+// matches it too
+func samplePath(g string) string {
+	g = glob.Expand(g)[0]
+	g = strings.ReplaceAll(g, "**/", "")
+	g = strings.ReplaceAll(g, "**", "x")
+
+	var b strings.Builder
+	for i := 0; i < len(g); i++ {
+		switch g[i] {
+		case '*', '?':
+			b.WriteByte('x')
+		case '[':
+			end := strings.IndexByte(g[i:], ']')
+			if end < 0 {
+				return b.String()
+			}
+			class := g[i+1 : i+end]
+			if class != "" && !strings.ContainsRune("!^-", rune(class[0])) {
+				b.WriteByte(class[0])
+			} else {
+				b.WriteByte('x')
+			}
+			i += end
+		case '\\':
+			if i+1 < len(g) {
+				i++
+				b.WriteByte(g[i])
+			}
+		default:
+			b.WriteByte(g[i])
+		}
+	}
+	return b.String()
+}
@@ -1,16 +1,21 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
+	"strings"
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
+	"rules-explorer/internal/core/claudemd"
+	"rules-explorer/internal/core/resolver"
 	"rules-explorer/internal/core/types"
 	"rules-explorer/internal/file"
 	"rules-explorer/internal/ui/input"
 	"rules-explorer/internal/ui/layout"
 	"rules-explorer/internal/ui/theme"
+	"rules-explorer/internal/utils"
 )
 
 type App struct {
@@ -25,33 +30,47 @@ type App struct {
 	allFiles      []types.FileItem
 	filteredFiles []types.FileItem
 	currentFile   *types.FileItem
+	regexMode     bool
+	resultsMode   bool
+	currentLine   int
+	
+	// Async search and loading; only touched on the UI goroutine
+	cancelSearch     context.CancelFunc
+	searchGeneration uint64
+	cancelLoad       context.CancelFunc
+	stopWatch        context.CancelFunc
+	lintGeneration   uint64
+	findingDupes     bool
+	findingConflicts bool
+	cancelHierarchy  context.CancelFunc
 }
 
-func New() *App {
-	config := NewConfig()
+func New(config *Config) *App {
 	appTheme := theme.New()
 	config.Theme = appTheme
 	
+	explorer := file.NewExplorer()
+	explorer.SetPatterns(config.Patterns)
+	explorer.SetNoIgnore(config.NoIgnore)
+	
 	return &App{
 		tvApp:    tview.NewApplication(),
 		config:   config,
 		theme:    appTheme,
-		explorer: file.NewExplorer(),
+		explorer: explorer,
 	}
 }
 
 func (a *App) Initialize() error {
-	// Load files
-	if err := a.explorer.LoadFiles(); err != nil {
-		return fmt.Errorf("failed to load files: %w", err)
-	}
-	
-	a.allFiles = a.explorer.GetAllFiles()
+	// Files are loaded in the background once the UI is up (see Run)
+	a.allFiles = make([]types.FileItem, 0)
 	a.filteredFiles = a.allFiles
 	
 	// Setup UI
 	a.layoutManager = layout.NewManager(a.theme)
 	a.keyHandler = input.NewKeyboardHandler(a.tvApp)
+	a.layoutManager.GetDetailsComponent().SetTokenBudget(a.config.TokenBudget)
+	a.layoutManager.GetStatsComponent().SetTokenBudget(a.config.TokenBudget)
 	
 	// Register components with keyboard handler
 	components := a.layoutManager.GetComponents()
@@ -83,6 +102,8 @@ func (a *App) setupEventHandlers() {
 	a.layoutManager.GetStatsComponent().SetEventHandler(a.handleEvent)
 	a.layoutManager.GetHelpComponent().SetEventHandler(a.handleEvent)
 	a.layoutManager.GetStatusBarComponent().SetEventHandler(a.handleEvent)
+	a.layoutManager.GetImportsComponent().SetEventHandler(a.handleEvent)
+	a.layoutManager.GetResultsComponent().SetEventHandler(a.handleEvent)
 }
 
 func (a *App) handleEvent(event types.Event) {
@@ -106,43 +127,151 @@ func (a *App) handleEvent(event types.Event) {
 	case types.EventRefresh:
 		a.handleRefresh()
 	case types.EventQuit:
+		a.handleCancelLoad()
+		a.stopWatching()
 		a.tvApp.Stop()
+	case types.EventCancelLoad:
+		a.handleCancelLoad()
 	case types.EventEditFile:
 		a.handleEditFile()
+	case types.EventResolveRules:
+		a.handleResolveRules()
+	case types.EventClaudeHierarchy:
+		a.handleClaudeHierarchy()
+	case types.EventShowImports:
+		a.handleShowImports()
+	case types.EventShowDuplicates:
+		a.handleShowDuplicates()
+	case types.EventShowConflicts:
+		a.handleShowConflicts()
+	case types.EventShowSettings:
+		a.handleShowSettings()
+	case types.EventToggleRegex:
+		a.handleToggleRegex()
+	case types.EventToggleResults:
+		a.handleToggleResults()
+	case types.EventLineSelected:
+		if lineEvent, ok := event.Data.(types.LineEvent); ok {
+			a.handleLineSelected(lineEvent)
+		}
+	case types.EventNextMatch:
+		a.layoutManager.GetPreviewComponent().NextMatch()
+	case types.EventPrevMatch:
+		a.layoutManager.GetPreviewComponent().PrevMatch()
 	}
 }
 
 func (a *App) handleSearchChanged(query string) {
-	a.filteredFiles = a.explorer.FilterFiles(query)
-	a.layoutManager.GetFileListComponent().Update(a.filteredFiles)
+	a.startSearch(query, false)
+}
+
+// applySearch shows the results of a finished search
+func (a *App) applySearch(result searchResult) {
+	query := result.query
+	a.layoutManager.GetSearchComponent().SetError(result.err)
+	if result.err != nil {
+		// Keep the previous results while the query is being typed
+		return
+	}
+	
+	a.filteredFiles = result.files
+	fileList := a.layoutManager.GetFileListComponent()
+	// Without a query the list is grouped by type; results keep their ranking
+	fileList.SetGrouped(query == "")
+	fileList.Update(a.filteredFiles)
 	a.layoutManager.GetStatsComponent().SetFilteredFiles(a.filteredFiles)
 	
-	// Update preview and details with first file if available
+	// Update preview and details with the first listed file if available, or
+	// stay on the current file after a refresh
+	selected := fileList.SelectedIndex()
+	if result.keepSelection && a.currentFile != nil {
+		if index, ok := fileList.SelectPath(a.currentFile.Path); ok {
+			selected = index
+		}
+	}
 	if len(a.filteredFiles) > 0 {
-		a.currentFile = &a.filteredFiles[0]
-		a.layoutManager.GetPreviewComponent().Update(*a.currentFile)
-		a.layoutManager.GetDetailsComponent().Update(*a.currentFile)
-		a.layoutManager.GetStatusBarComponent().Update(*a.currentFile)
+		a.showFile(a.filteredFiles[selected])
 	} else {
 		a.currentFile = nil
-		a.layoutManager.GetPreviewComponent().Update(fmt.Sprintf("[red]No files found[-]\n\n[white]Total files loaded: %d\nFilter: '%s'[-]", len(a.allFiles), query))
+		a.layoutManager.GetPreviewComponent().Update(fmt.Sprintf("[red]No files found[-]\n\n[white]Total files loaded: %d\nFilter: '%s'[-]", len(a.allFiles), tview.Escape(query)))
 		a.layoutManager.GetDetailsComponent().SetNoFileSelected()
 		a.layoutManager.GetStatusBarComponent().Update("")
 	}
 	
 	a.layoutManager.GetStatusBarComponent().SetCounts(len(a.filteredFiles), len(a.allFiles))
+	
+	if a.resultsMode {
+		if result.grepped {
+			a.currentLine = 0
+			a.layoutManager.GetResultsComponent().Update(result.lines)
+		} else {
+			a.updateResults()
+		}
+	}
 }
 
-func (a *App) handleFileSelected(file types.FileItem, index int) {
+func (a *App) handleToggleResults() {
+	a.resultsMode = !a.resultsMode
+	a.currentLine = 0
+	a.layoutManager.ShowResults(a.resultsMode)
+	
+	if a.resultsMode {
+		a.keyHandler.RegisterComponent(types.FocusFileList, a.layoutManager.GetResultsComponent())
+		a.updateResults()
+	} else {
+		a.keyHandler.RegisterComponent(types.FocusFileList, a.layoutManager.GetFileListComponent())
+		if a.currentFile != nil {
+			a.showFile(*a.currentFile)
+		}
+	}
+	a.keyHandler.SetCurrentFocus(a.keyHandler.GetCurrentFocus())
+}
+
+// updateResults greps the filtered files for the current query
+func (a *App) updateResults() {
+	lines := grepLines(a.layoutManager.GetSearchComponent().GetText(), a.regexMode, a.filteredFiles)
+	if lines == nil {
+		return
+	}
+	
+	a.currentLine = 0
+	a.layoutManager.GetResultsComponent().Update(lines)
+}
+
+func (a *App) handleLineSelected(event types.LineEvent) {
+	file := event.File
 	a.currentFile = &file
-	a.layoutManager.GetPreviewComponent().Update(file)
-	a.layoutManager.GetDetailsComponent().Update(file)
-	a.layoutManager.GetStatusBarComponent().Update(file)
+	a.currentLine = event.Line
+	
+	a.layoutManager.GetPreviewComponent().SetFileContentAt(file, a.explorer.Highlights(file.Content), event.Offset)
+	if match, ok := a.layoutManager.GetResultsComponent().GetCurrentMatch(); ok {
+		a.layoutManager.GetDetailsComponent().ShowLineMatch(match)
+	}
+	a.layoutManager.GetStatusBarComponent().Update(fmt.Sprintf("%s:%d", utils.GetBaseName(file.Path), event.Line))
+}
+
+func (a *App) handleToggleRegex() {
+	a.regexMode = !a.regexMode
+	a.explorer.SetRegexMode(a.regexMode)
+	
+	search := a.layoutManager.GetSearchComponent()
+	search.SetRegexMode(a.regexMode)
+	a.handleSearchChanged(search.GetText())
+}
+
+func (a *App) handleFileSelected(file types.FileItem, index int) {
+	a.showFile(file)
 }
 
 func (a *App) handleFileChanged(file types.FileItem, index int) {
+	a.showFile(file)
+}
+
+// showFile makes file current and shows it with the query matches highlighted
+func (a *App) showFile(file types.FileItem) {
 	a.currentFile = &file
-	a.layoutManager.GetPreviewComponent().Update(file)
+	a.currentLine = 0
+	a.layoutManager.GetPreviewComponent().SetFileContent(file, a.explorer.Highlights(file.Content))
 	a.layoutManager.GetDetailsComponent().Update(file)
 	a.layoutManager.GetStatusBarComponent().Update(file)
 }
@@ -152,16 +281,27 @@ func (a *App) handleFocusChanged(focus types.Focus) {
 	// This is just for any additional logic if needed
 }
 
+// handleRefresh rescans the directory in the background, like the initial
+// load
 func (a *App) handleRefresh() {
-	if err := a.explorer.LoadFiles(); err != nil {
+	if a.cancelLoad != nil {
 		return
 	}
 	
+	a.stopWatching()
+	a.allFiles = nil
+	a.filteredFiles = nil
+	a.layoutManager.GetFileListComponent().Update(a.filteredFiles)
+	a.startLoading()
+}
+
+// reloadFiles picks up the explorer's current files and re-applies the query,
+// keeping the selection on the same path
+func (a *App) reloadFiles() {
 	a.allFiles = a.explorer.GetAllFiles()
-	searchQuery := a.layoutManager.GetSearchComponent().GetText()
-	a.filteredFiles = a.explorer.FilterFiles(searchQuery)
-	
-	a.updateAllComponents()
+	a.layoutManager.GetStatsComponent().Update(a.allFiles)
+	a.startSearch(a.layoutManager.GetSearchComponent().GetText(), true)
+	a.startLint()
 }
 
 func (a *App) handleEditFile() {
@@ -184,33 +324,101 @@ func (a *App) handleEditFile() {
 	
 	// Suspend the tview application temporarily
 	a.tvApp.Suspend(func() {
-		// Open editor
-		cmd := exec.Command(editor, a.currentFile.Path)
+		// Open editor, at the selected line in results mode
+		args := []string{a.currentFile.Path}
+		if a.resultsMode && a.currentLine > 0 {
+			args = append([]string{fmt.Sprintf("+%d", a.currentLine)}, args...)
+		}
+		cmd := exec.Command(editor, args...)
 		cmd.Stdin = os.Stdin
 		cmd.Stdout = os.Stdout
 		cmd.Stderr = os.Stderr
 		
-		if err := cmd.Run(); err == nil {
-			// Refresh after editing
+		// The watcher picks up the edit; without one the directory is
+		// rescanned
+		if err := cmd.Run(); err == nil && a.stopWatch == nil {
 			a.handleRefresh()
 		}
 	})
 }
 
+// showPrompt opens the modal prompt and restores focus when it closes
+func (a *App) showPrompt(title, label, text string, onAccept func(text string)) {
+	a.keyHandler.SetModal(true)
+	a.layoutManager.ShowPrompt(title, label, text, func(text string, accepted bool) {
+		a.keyHandler.SetModal(false)
+		a.keyHandler.SetCurrentFocus(a.keyHandler.GetCurrentFocus())
+		if accepted && strings.TrimSpace(text) != "" {
+			onAccept(strings.TrimSpace(text))
+		}
+	})
+	a.tvApp.SetFocus(a.layoutManager.GetPromptComponent().GetInput())
+}
+
+func (a *App) handleResolveRules() {
+	a.showPrompt("Which rules apply to this file?", "Path: ", "", a.showAppliedRules)
+}
+
+func (a *App) showAppliedRules(target string) {
+	target = resolver.Clean(target)
+	matches := resolver.Resolve(a.allFiles, target)
+	
+	var b strings.Builder
+	if len(matches) == 0 {
+		fmt.Fprintf(&b, "[yellow]No Cursor rules apply to[-] %s\n", tview.Escape(target))
+		a.layoutManager.GetPreviewComponent().Update(b.String())
+		return
+	}
+	
+	fmt.Fprintf(&b, "[yellow]Rules applying to[-] %s\n\n", tview.Escape(target))
+	for _, match := range matches {
+		switch match.Reason {
+		case resolver.ReasonAlways:
+			fmt.Fprintf(&b, "[green]always[-]  %s", tview.Escape(match.File.Path))
+		case resolver.ReasonGlob:
+			fmt.Fprintf(&b, "[aqua]glob[-]    %s [gray](matched %s)[-]", tview.Escape(match.File.Path), tview.Escape(match.Glob))
+		}
+		if match.Scope != "" {
+			fmt.Fprintf(&b, " [gray]scoped to %s/[-]", tview.Escape(match.Scope))
+		}
+		b.WriteString("\n")
+	}
+	
+	for _, match := range matches {
+		fmt.Fprintf(&b, "\n[yellow]── %s ──[-]\n%s\n", tview.Escape(match.File.Path), tview.Escape(match.File.Content))
+	}
+	
+	a.layoutManager.GetPreviewComponent().Update(b.String())
+}
+
+func (a *App) handleShowImports() {
+	if a.currentFile == nil {
+		return
+	}
+	
+	imports := claudemd.ResolveImports(a.currentFile.Path, a.currentFile.Content)
+	
+	a.keyHandler.SetModal(true)
+	a.layoutManager.ShowImports(*a.currentFile, imports, func() {
+		a.keyHandler.SetModal(false)
+		a.keyHandler.SetCurrentFocus(a.keyHandler.GetCurrentFocus())
+	})
+	a.tvApp.SetFocus(a.layoutManager.GetImportsComponent().GetTree())
+}
+
 func (a *App) updateAllComponents() {
 	// Update file list
-	a.layoutManager.GetFileListComponent().Update(a.filteredFiles)
+	fileList := a.layoutManager.GetFileListComponent()
+	fileList.SetGrouped(a.layoutManager.GetSearchComponent().GetText() == "")
+	fileList.Update(a.filteredFiles)
 	
 	// Update stats
 	a.layoutManager.GetStatsComponent().Update(a.allFiles)
 	a.layoutManager.GetStatsComponent().SetFilteredFiles(a.filteredFiles)
 	
-	// Update preview and details with first file if available
+	// Update preview and details with the first listed file if available
 	if len(a.filteredFiles) > 0 {
-		a.currentFile = &a.filteredFiles[0]
-		a.layoutManager.GetPreviewComponent().Update(*a.currentFile)
-		a.layoutManager.GetDetailsComponent().Update(*a.currentFile)
-		a.layoutManager.GetStatusBarComponent().Update(*a.currentFile)
+		a.showFile(a.filteredFiles[fileList.SelectedIndex()])
 	} else {
 		a.currentFile = nil
 		a.layoutManager.GetDetailsComponent().SetNoFileSelected()
@@ -232,5 +440,7 @@ func (a *App) Run() error {
 		return false
 	})
 	
+	a.startLoading()
+	
 	return a.tvApp.SetRoot(a.layoutManager.GetRoot(), true).Run()
 }
\ No newline at end of file
@@ -1,16 +1,43 @@
 package app
 
 import (
+	"os"
+	"rules-explorer/internal/config"
+	"rules-explorer/internal/core/patterns"
+	"rules-explorer/internal/core/tokens"
 	"rules-explorer/internal/core/types"
 )
 
 type Config struct {
 	Theme        types.Theme
 	InitialFocus types.Focus
+	ConfigPath   string
+	Patterns     *patterns.Registry
+	NoIgnore     bool
+	TokenBudget  tokens.Budget
 }
 
 func NewConfig() *Config {
 	return &Config{
 		InitialFocus: types.FocusSearch,
+		Patterns:     patterns.Default(),
+		TokenBudget:  tokens.DefaultBudget,
 	}
+}
+
+// Load reads the config file (explicit ConfigPath or discovered) and applies it
+func (c *Config) Load() error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	file, err := config.Load(c.ConfigPath, cwd)
+	if err != nil {
+		return err
+	}
+
+	c.Patterns = file.Registry()
+	c.TokenBudget = file.Budget()
+	return tokens.Use(file.Estimator())
 }
\ No newline at end of file
@@ -0,0 +1,70 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"rules-explorer/internal/analysis"
+	"rules-explorer/internal/core/types"
+	"rules-explorer/internal/utils"
+)
+
+// handleShowConflicts looks for contradicting instructions across the loaded
+// files in the background and shows them in a modal
+func (a *App) handleShowConflicts() {
+	if a.findingConflicts {
+		return
+	}
+	a.findingConflicts = true
+	statusBar := a.layoutManager.GetStatusBarComponent()
+	statusBar.SetNote("[aqua](finding conflicts…)[-]")
+
+	files := a.allFiles
+	go func() {
+		conflicts, err := analysis.FindConflicts(context.Background(), files)
+		a.tvApp.QueueUpdateDraw(func() {
+			a.findingConflicts = false
+			if err != nil {
+				statusBar.SetNote(fmt.Sprintf("[red](conflicts: %v)[-]", err))
+				return
+			}
+			statusBar.SetNote(fmt.Sprintf("[aqua](%d conflicts)[-]", len(conflicts)))
+			a.showConflicts(conflicts)
+		})
+	}()
+}
+
+func (a *App) showConflicts(conflicts []analysis.Conflict) {
+	a.keyHandler.SetModal(true)
+	a.layoutManager.ShowConflicts(conflicts, a.showStatement, func() {
+		a.keyHandler.SetModal(false)
+		a.keyHandler.SetCurrentFocus(a.keyHandler.GetCurrentFocus())
+	})
+	a.tvApp.SetFocus(a.layoutManager.GetConflictsComponent().GetList())
+}
+
+// showStatement previews a file with the line of the statement highlighted
+func (a *App) showStatement(statement analysis.Statement) {
+	file := statement.File
+	a.currentFile = &file
+	a.currentLine = statement.Line
+
+	start := 0
+	for line := 1; line < statement.Line; line++ {
+		next := strings.IndexByte(file.Content[start:], '\n')
+		if next < 0 {
+			break
+		}
+		start += next + 1
+	}
+	end := len(file.Content)
+	if next := strings.IndexByte(file.Content[start:], '\n'); next >= 0 {
+		end = start + next
+	}
+
+	spans := []types.Span{{Start: start, End: end}}
+	a.layoutManager.GetPreviewComponent().SetFileContentAt(file, spans, start)
+	a.layoutManager.GetDetailsComponent().Update(file)
+	a.layoutManager.GetStatusBarComponent().Update(fmt.Sprintf("%s:%d", utils.GetBaseName(file.Path), statement.Line))
+}
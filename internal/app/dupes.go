@@ -0,0 +1,56 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"rules-explorer/internal/analysis"
+	"rules-explorer/internal/core/types"
+	"rules-explorer/internal/utils"
+)
+
+// handleShowDuplicates finds duplicated paragraphs across the loaded files in
+// the background and shows them in a modal
+func (a *App) handleShowDuplicates() {
+	if a.findingDupes {
+		return
+	}
+	a.findingDupes = true
+	statusBar := a.layoutManager.GetStatusBarComponent()
+	statusBar.SetNote("[aqua](finding duplicates…)[-]")
+
+	files := a.allFiles
+	go func() {
+		clusters, err := analysis.FindDuplicates(context.Background(), files, analysis.DefaultDupeOptions)
+		a.tvApp.QueueUpdateDraw(func() {
+			a.findingDupes = false
+			if err != nil {
+				statusBar.SetNote(fmt.Sprintf("[red](duplicates: %v)[-]", err))
+				return
+			}
+			statusBar.SetNote(fmt.Sprintf("[aqua](%d duplicated paragraphs)[-]", len(clusters)))
+			a.showDuplicates(clusters)
+		})
+	}()
+}
+
+func (a *App) showDuplicates(clusters []analysis.Cluster) {
+	a.keyHandler.SetModal(true)
+	a.layoutManager.ShowDupes(clusters, a.showParagraph, func() {
+		a.keyHandler.SetModal(false)
+		a.keyHandler.SetCurrentFocus(a.keyHandler.GetCurrentFocus())
+	})
+	a.tvApp.SetFocus(a.layoutManager.GetDupesComponent().GetTree())
+}
+
+// showParagraph previews a file with the paragraph highlighted
+func (a *App) showParagraph(paragraph analysis.Paragraph) {
+	file := paragraph.File
+	a.currentFile = &file
+	a.currentLine = paragraph.Line
+
+	spans := []types.Span{{Start: paragraph.Start, End: paragraph.End}}
+	a.layoutManager.GetPreviewComponent().SetFileContentAt(file, spans, paragraph.Start)
+	a.layoutManager.GetDetailsComponent().Update(file)
+	a.layoutManager.GetStatusBarComponent().Update(fmt.Sprintf("%s:%d", utils.GetBaseName(file.Path), paragraph.Line))
+}
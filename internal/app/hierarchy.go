@@ -0,0 +1,80 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rivo/tview"
+	"rules-explorer/internal/core/claudemd"
+	"rules-explorer/internal/core/ignore"
+)
+
+func (a *App) handleClaudeHierarchy() {
+	initial := "."
+	if a.currentFile != nil {
+		initial = filepath.Dir(a.currentFile.Path)
+	}
+	a.showPrompt("Effective CLAUDE.md hierarchy", "Directory: ", initial, a.showClaudeHierarchy)
+}
+
+// showClaudeHierarchy walks target for on-demand CLAUDE.md files in the
+// background. Asking for another directory cancels a walk that hasn't
+// finished.
+func (a *App) showClaudeHierarchy(target string) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return
+	}
+
+	if a.cancelHierarchy != nil {
+		a.cancelHierarchy()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	a.cancelHierarchy = cancel
+
+	var matcher *ignore.Matcher
+	if !a.config.NoIgnore {
+		matcher = ignore.New(cwd)
+	}
+	statusBar := a.layoutManager.GetStatusBarComponent()
+	statusBar.SetNote("[aqua](reading CLAUDE.md hierarchy…)[-]")
+
+	go func() {
+		entries, err := claudemd.Hierarchy(ctx, cwd, target, matcher)
+		a.tvApp.QueueUpdateDraw(func() {
+			if ctx.Err() != nil {
+				// A newer walk replaced this one
+				return
+			}
+			cancel()
+			a.cancelHierarchy = nil
+			statusBar.SetNote("")
+			if err != nil {
+				a.layoutManager.GetPreviewComponent().Update(fmt.Sprintf("[red]%s[-]", tview.Escape(err.Error())))
+				return
+			}
+			a.showHierarchy(target, entries)
+		})
+	}()
+}
+
+func (a *App) showHierarchy(target string, entries []claudemd.Entry) {
+	a.layoutManager.GetDetailsComponent().ShowHierarchy(target, entries)
+
+	var b strings.Builder
+	for _, entry := range entries {
+		if entry.OnDemand() {
+			continue
+		}
+		fmt.Fprintf(&b, "[yellow]── %s [gray](%s)[yellow] ──[-]\n%s\n\n",
+			tview.Escape(entry.DisplayPath), entry.Scope.String(), tview.Escape(strings.TrimRight(entry.Content, "\n")))
+	}
+	if b.Len() == 0 {
+		b.WriteString("[yellow]No CLAUDE.md files are loaded for this directory[-]")
+	}
+
+	a.layoutManager.GetPreviewComponent().Update(b.String())
+}
@@ -0,0 +1,24 @@
+package app
+
+import (
+	"rules-explorer/internal/lint"
+)
+
+// startLint runs the linter over the loaded files in the background and
+// shows the findings as badges in the file list. A newer run supersedes an
+// older one that hasn't finished.
+func (a *App) startLint() {
+	a.lintGeneration++
+	generation := a.lintGeneration
+	files := a.allFiles
+
+	go func() {
+		badges := lint.ByPath(lint.Run(files, lint.Options{}))
+		a.tvApp.QueueUpdateDraw(func() {
+			if generation != a.lintGeneration {
+				return
+			}
+			a.layoutManager.GetFileListComponent().SetBadges(badges)
+		})
+	}()
+}
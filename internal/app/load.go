@@ -0,0 +1,80 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"rules-explorer/internal/core/types"
+)
+
+// startLoading scans the working directory in the background. Files are
+// streamed into the list as the walk finds them; Escape cancels the scan and
+// keeps what was found so far.
+func (a *App) startLoading() {
+	ctx, cancel := context.WithCancel(context.Background())
+	a.cancelLoad = cancel
+	a.keyHandler.SetLoading(true)
+	a.layoutManager.GetStatusBarComponent().SetLoading(0)
+
+	go func() {
+		err := a.explorer.LoadFilesContext(ctx, func(progress types.LoadProgress) {
+			a.tvApp.QueueUpdateDraw(func() {
+				a.applyLoadProgress(progress)
+			})
+		})
+		a.tvApp.QueueUpdateDraw(func() {
+			a.finishLoading(err)
+		})
+	}()
+}
+
+func (a *App) applyLoadProgress(progress types.LoadProgress) {
+	statusBar := a.layoutManager.GetStatusBarComponent()
+
+	if len(progress.Files) > 0 {
+		a.allFiles = append(a.allFiles, progress.Files...)
+		a.layoutManager.GetStatsComponent().Update(a.allFiles)
+
+		query := a.layoutManager.GetSearchComponent().GetText()
+		if query == "" {
+			// Unfiltered: new files go to the end without moving the selection
+			a.filteredFiles = a.allFiles
+			a.layoutManager.GetFileListComponent().Append(progress.Files)
+			a.layoutManager.GetStatsComponent().SetFilteredFiles(a.filteredFiles)
+		} else {
+			a.startSearch(query, true)
+		}
+	}
+
+	statusBar.SetCounts(len(a.filteredFiles), len(a.allFiles))
+	statusBar.SetLoading(progress.Scanned)
+}
+
+func (a *App) finishLoading(err error) {
+	a.cancelLoad = nil
+	a.keyHandler.SetLoading(false)
+	a.allFiles = a.explorer.GetAllFiles()
+
+	note := ""
+	if errors.Is(err, context.Canceled) {
+		note = "[red](scan cancelled)[-]"
+	} else if err != nil {
+		note = fmt.Sprintf("[red](scan failed: %v)[-]", err)
+	}
+
+	statusBar := a.layoutManager.GetStatusBarComponent()
+	statusBar.SetCounts(len(a.filteredFiles), len(a.allFiles))
+	statusBar.SetLoaded(note)
+	a.startLint()
+
+	if err == nil {
+		a.startWatching()
+	}
+}
+
+func (a *App) handleCancelLoad() {
+	if a.cancelLoad != nil {
+		a.cancelLoad()
+	}
+}
@@ -0,0 +1,79 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"rules-explorer/internal/core/search"
+	"rules-explorer/internal/core/types"
+)
+
+// searchDebounce is how long typing has to pause before a search starts
+const searchDebounce = 80 * time.Millisecond
+
+// searchResult is what a search worker hands back to the UI goroutine
+type searchResult struct {
+	query string
+	files []types.FileItem
+	lines []search.LineMatch
+	// grepped is set when lines were computed for the results view
+	grepped       bool
+	keepSelection bool
+	err           error
+}
+
+// startSearch filters on a worker goroutine once typing pauses. Starting a
+// new search cancels the previous one, and results are only applied if no
+// newer search has started since. With keepSelection the current file stays
+// selected if it is still in the results.
+func (a *App) startSearch(query string, keepSelection bool) {
+	if a.cancelSearch != nil {
+		a.cancelSearch()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	a.cancelSearch = cancel
+	a.searchGeneration++
+	generation := a.searchGeneration
+
+	regexMode := a.regexMode
+	resultsMode := a.resultsMode
+
+	go func() {
+		timer := time.NewTimer(searchDebounce)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return
+		}
+
+		result := searchResult{query: query, keepSelection: keepSelection}
+		result.files, result.err = a.explorer.FilterFilesContext(ctx, query)
+		if ctx.Err() != nil {
+			return
+		}
+		if result.err == nil && resultsMode {
+			result.lines = grepLines(query, regexMode, result.files)
+			result.grepped = true
+		}
+
+		a.tvApp.QueueUpdateDraw(func() {
+			if generation != a.searchGeneration {
+				// A newer query is on its way
+				return
+			}
+			a.applySearch(result)
+		})
+	}()
+}
+
+// grepLines runs the line-level search for query over files
+func grepLines(query string, regexMode bool, files []types.FileItem) []search.LineMatch {
+	filter := search.NewFilter()
+	filter.SetRegexMode(regexMode)
+	filter.SetFields(search.FieldNames(files))
+	if err := filter.SetQuery(query); err != nil {
+		return nil
+	}
+	return filter.GrepLines(files, 2)
+}
@@ -0,0 +1,28 @@
+package app
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/rivo/tview"
+	"rules-explorer/internal/core/settings"
+	"rules-explorer/internal/ui/components"
+)
+
+// handleShowSettings shows the Claude Code settings merged from the user,
+// project and local scopes, marking where each value comes from
+func (a *App) handleShowSettings() {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return
+	}
+
+	files, err := settings.Load(cwd)
+	if err != nil {
+		a.layoutManager.GetPreviewComponent().Update(fmt.Sprintf("[red]%s[-]", tview.Escape(err.Error())))
+		return
+	}
+
+	a.layoutManager.GetDetailsComponent().ShowSettings(files)
+	a.layoutManager.GetPreviewComponent().Update(components.FormatEffectiveSettings(files, settings.Merge(files)))
+}
@@ -0,0 +1,45 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"rules-explorer/internal/utils"
+)
+
+// startWatching refreshes the file list when matched files are created,
+// changed, removed or renamed on disk
+func (a *App) startWatching() {
+	ctx, cancel := context.WithCancel(context.Background())
+	err := a.explorer.Watch(ctx, func(paths []string) {
+		a.tvApp.QueueUpdateDraw(func() {
+			a.handleFilesChanged(paths)
+		})
+	})
+	if err != nil {
+		cancel()
+		a.layoutManager.GetStatusBarComponent().SetNote(fmt.Sprintf("[red](not watching: %v)[-]", err))
+		return
+	}
+	a.stopWatch = cancel
+}
+
+func (a *App) stopWatching() {
+	if a.stopWatch != nil {
+		a.stopWatch()
+		a.stopWatch = nil
+	}
+}
+
+func (a *App) handleFilesChanged(paths []string) {
+	note := fmt.Sprintf("[aqua](%d files changed)[-]", len(paths))
+	switch {
+	case len(paths) == 1 && paths[0] == ".":
+		note = "[aqua](reloaded)[-]"
+	case len(paths) == 1:
+		note = fmt.Sprintf("[aqua](%s changed)[-]", utils.GetBaseName(paths[0]))
+	}
+	a.layoutManager.GetStatusBarComponent().SetNote(note)
+
+	a.reloadFiles()
+}
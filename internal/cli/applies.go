@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"text/tabwriter"
+
+	"rules-explorer/internal/core/resolver"
+)
+
+func init() {
+	register(&Command{
+		Name:    "applies",
+		Usage:   "applies [flags] <path>",
+		Summary: "List the Cursor rules that attach to a path",
+		Run:     runApplies,
+	})
+}
+
+func runApplies(command *Command, args []string, stdout, stderr io.Writer) int {
+	flags := newFlagSet(command, stderr)
+	opts := &options{}
+	opts.register(flags)
+
+	if err := flags.Parse(args); err != nil {
+		return ExitError
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return ExitError
+	}
+
+	root, err := opts.rootDir()
+	if err != nil {
+		fmt.Fprintf(stderr, "rules-explorer: %v\n", err)
+		return ExitError
+	}
+	target, err := relativeTo(root, flags.Arg(0))
+	if err != nil {
+		fmt.Fprintf(stderr, "rules-explorer: %v\n", err)
+		return ExitError
+	}
+
+	explorer, err := opts.loadExplorer()
+	if err != nil {
+		fmt.Fprintf(stderr, "rules-explorer: %v\n", err)
+		return ExitError
+	}
+
+	matches := resolver.Resolve(explorer.GetAllFiles(), target)
+	if len(matches) == 0 {
+		fmt.Fprintf(stdout, "No Cursor rules apply to %s\n", target)
+		return ExitNoMatch
+	}
+
+	fmt.Fprintf(stdout, "Rules applying to %s:\n\n", target)
+	w := tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)
+	for _, match := range matches {
+		fmt.Fprintf(w, "  %s\t%s\t%s\n", match.Reason, match.File.Path, describeMatch(match))
+	}
+	w.Flush()
+
+	return ExitOK
+}
+
+func describeMatch(match resolver.Match) string {
+	detail := ""
+	if match.Reason == resolver.ReasonGlob {
+		detail = match.Glob
+	}
+	if match.Scope != "" {
+		if detail != "" {
+			detail += ", "
+		}
+		detail += "scoped to " + match.Scope + "/"
+	}
+	if detail == "" {
+		return ""
+	}
+	return "(" + detail + ")"
+}
+
+// relativeTo makes p, given relative to the working directory or absolute,
+// relative to root
+func relativeTo(root, p string) (string, error) {
+	abs, err := filepath.Abs(p)
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(root, abs)
+	if err != nil {
+		return "", err
+	}
+	return resolver.Clean(rel), nil
+}
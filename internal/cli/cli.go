@@ -0,0 +1,184 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"rules-explorer/internal/config"
+	"rules-explorer/internal/core/glob"
+	"rules-explorer/internal/core/patterns"
+	"rules-explorer/internal/core/tokens"
+	"rules-explorer/internal/file"
+)
+
+// Exit codes shared by every command
+const (
+	ExitOK      = 0
+	ExitNoMatch = 1
+	// ExitFindings is returned by lint when a finding reaches --fail-on, and
+	// by dupes and conflicts when they find anything
+	ExitFindings = 1
+	ExitError    = 2
+)
+
+type Command struct {
+	Name    string
+	Usage   string
+	Summary string
+	Run     func(command *Command, args []string, stdout, stderr io.Writer) int
+}
+
+func (c *Command) Execute(args []string, stdout, stderr io.Writer) int {
+	return c.Run(c, args, stdout, stderr)
+}
+
+var commands = make(map[string]*Command)
+
+func register(command *Command) {
+	commands[command.Name] = command
+}
+
+func Lookup(name string) (*Command, bool) {
+	command, ok := commands[name]
+	return command, ok
+}
+
+func Commands() []*Command {
+	list := make([]*Command, 0, len(commands))
+	for _, command := range commands {
+		list = append(list, command)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+	return list
+}
+
+// PrintCommands writes the command summary used in the top-level usage text.
+func PrintCommands(w io.Writer) {
+	fmt.Fprintln(w, "Commands:")
+	for _, command := range Commands() {
+		fmt.Fprintf(w, "  %-10s %s\n", command.Name, command.Summary)
+	}
+}
+
+// options are the discovery flags accepted by every command
+type options struct {
+	configPath string
+	noIgnore   bool
+	root       string
+	patterns   stringList
+	exclude    stringList
+	noDefaults bool
+
+	// settings is the config file, set by loadExplorer
+	settings *config.File
+}
+
+func (o *options) register(flags *flag.FlagSet) {
+	flags.StringVar(&o.configPath, "config", "", "path to a rules-explorer config file")
+	flags.BoolVar(&o.noIgnore, "no-ignore", false, "don't respect .gitignore files or skip heavy directories")
+	flags.StringVar(&o.root, "root", "", "directory to scan instead of the working directory")
+	flags.Var(&o.patterns, "pattern", "add a file `pattern`, written [type=]glob; repeatable")
+	flags.Var(&o.exclude, "exclude", "`glob` of paths to leave out; repeatable")
+	flags.BoolVar(&o.noDefaults, "no-defaults", false, "only use the patterns given with --pattern")
+}
+
+// rootDir is the absolute directory being scanned
+func (o *options) rootDir() (string, error) {
+	if o.root == "" {
+		return os.Getwd()
+	}
+	return filepath.Abs(o.root)
+}
+
+// registry builds the pattern registry from the config file and flags.
+// Patterns from flags are tried first.
+func (o *options) registry(settings *config.File) (*patterns.Registry, error) {
+	list := make([]patterns.Pattern, 0)
+	for _, value := range o.patterns {
+		label, include, found := strings.Cut(value, "=")
+		if !found {
+			label, include = "", value
+		}
+		if err := glob.Validate(include); err != nil {
+			return nil, fmt.Errorf("--pattern %q: %w", value, err)
+		}
+		list = append(list, patterns.Pattern{Name: value, Type: label, Include: []string{include}})
+	}
+	for _, value := range o.exclude {
+		if err := glob.Validate(value); err != nil {
+			return nil, fmt.Errorf("--exclude %q: %w", value, err)
+		}
+	}
+
+	if o.noDefaults {
+		if len(list) == 0 {
+			return nil, errors.New("--no-defaults needs at least one --pattern")
+		}
+		return patterns.NewRegistry(list, o.exclude), nil
+	}
+
+	registry := settings.Registry()
+	list = append(list, registry.Patterns()...)
+	return patterns.NewRegistry(list, append(registry.Excludes(), o.exclude...)), nil
+}
+
+func (o *options) loadExplorer() (*file.Explorer, error) {
+	root, err := o.rootDir()
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := config.Load(o.configPath, root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := tokens.Use(settings.Estimator()); err != nil {
+		return nil, err
+	}
+	o.settings = settings
+
+	registry, err := o.registry(settings)
+	if err != nil {
+		return nil, err
+	}
+
+	explorer := file.NewExplorer()
+	explorer.SetRoot(root)
+	explorer.SetPatterns(registry)
+	explorer.SetNoIgnore(o.noIgnore)
+
+	if err := explorer.LoadFiles(); err != nil {
+		return nil, fmt.Errorf("failed to load files: %w", err)
+	}
+	return explorer, nil
+}
+
+// stringList is a repeatable string flag
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+func newFlagSet(command *Command, stderr io.Writer) *flag.FlagSet {
+	flags := flag.NewFlagSet(command.Name, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: rules-explorer %s\n\n%s\n\nFlags:\n", command.Usage, command.Summary)
+		flags.PrintDefaults()
+	}
+	return flags
+}
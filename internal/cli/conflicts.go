@@ -0,0 +1,149 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"rules-explorer/internal/analysis"
+	"rules-explorer/internal/utils"
+)
+
+func init() {
+	register(&Command{
+		Name:    "conflicts",
+		Usage:   "conflicts [flags]",
+		Summary: "Find instructions that contradict each other across files",
+		Run:     runConflicts,
+	})
+}
+
+type conflictRecord struct {
+	Topic   string          `json:"topic"`
+	Require statementRecord `json:"require"`
+	Forbid  statementRecord `json:"forbid"`
+}
+
+type statementRecord struct {
+	Path    string   `json:"path"`
+	Line    int      `json:"line"`
+	Trigger string   `json:"trigger"`
+	Subject []string `json:"subject"`
+	Text    string   `json:"text"`
+}
+
+func runConflicts(command *Command, args []string, stdout, stderr io.Writer) int {
+	flags := newFlagSet(command, stderr)
+	opts := &options{}
+	opts.register(flags)
+	jsonOutput := flags.Bool("json", false, "print conflicts as JSON")
+
+	if err := flags.Parse(args); err != nil {
+		return ExitError
+	}
+	if flags.NArg() != 0 {
+		flags.Usage()
+		return ExitError
+	}
+
+	explorer, err := opts.loadExplorer()
+	if err != nil {
+		fmt.Fprintf(stderr, "rules-explorer: %v\n", err)
+		return ExitError
+	}
+
+	conflicts, err := analysis.FindConflicts(context.Background(), explorer.GetAllFiles())
+	if err != nil {
+		fmt.Fprintf(stderr, "rules-explorer: %v\n", err)
+		return ExitError
+	}
+
+	if *jsonOutput {
+		err = writeConflictsJSON(stdout, conflicts)
+	} else {
+		err = writeConflicts(stdout, conflicts)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "rules-explorer: %v\n", err)
+		return ExitError
+	}
+
+	if len(conflicts) > 0 {
+		return ExitFindings
+	}
+	return ExitOK
+}
+
+func newStatementRecord(s analysis.Statement) statementRecord {
+	return statementRecord{Path: s.File.Path, Line: s.Line, Trigger: s.Trigger, Subject: s.Subject, Text: s.Text}
+}
+
+func writeConflictsJSON(w io.Writer, conflicts []analysis.Conflict) error {
+	records := make([]conflictRecord, 0, len(conflicts))
+	for _, conflict := range conflicts {
+		records = append(records, conflictRecord{
+			Topic:   conflict.Topic(),
+			Require: newStatementRecord(conflict.Require),
+			Forbid:  newStatementRecord(conflict.Forbid),
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(records)
+}
+
+// conflictColumn is the width of each side when statements are printed
+// next to each other
+const conflictColumn = 48
+
+func writeConflicts(w io.Writer, conflicts []analysis.Conflict) error {
+	if len(conflicts) == 0 {
+		fmt.Fprintln(w, "No conflicts found")
+		return nil
+	}
+
+	for i, conflict := range conflicts {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "%d. %s\n", i+1, conflict.Topic())
+		left := sideBySide(conflict.Require)
+		right := sideBySide(conflict.Forbid)
+		for row := 0; row < max(len(left), len(right)); row++ {
+			var a, b string
+			if row < len(left) {
+				a = left[row]
+			}
+			if row < len(right) {
+				b = right[row]
+			}
+			line := fmt.Sprintf("   %-*s │ %s", conflictColumn, a, b)
+			fmt.Fprintln(w, strings.TrimRight(line, " "))
+		}
+	}
+	return nil
+}
+
+// sideBySide lays a statement out as the rows of one column: its location
+// followed by the sentence wrapped to the column width
+func sideBySide(s analysis.Statement) []string {
+	rows := []string{utils.GetShortPath(s.Location(), conflictColumn)}
+	var line strings.Builder
+	for _, word := range strings.Fields(s.Text) {
+		if line.Len() > 0 && line.Len()+1+len(word) > conflictColumn-2 {
+			rows = append(rows, "  "+line.String())
+			line.Reset()
+		}
+		if line.Len() > 0 {
+			line.WriteByte(' ')
+		}
+		line.WriteString(word)
+	}
+	if line.Len() > 0 {
+		rows = append(rows, "  "+line.String())
+	}
+	return rows
+}
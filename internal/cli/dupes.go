@@ -0,0 +1,136 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"rules-explorer/internal/analysis"
+	"rules-explorer/internal/utils"
+)
+
+func init() {
+	register(&Command{
+		Name:    "dupes",
+		Usage:   "dupes [flags]",
+		Summary: "Find paragraphs copied across discovered files",
+		Run:     runDupes,
+	})
+}
+
+type clusterRecord struct {
+	Exact      bool              `json:"exact"`
+	Similarity float64           `json:"similarity"`
+	Files      int               `json:"files"`
+	Copies     []paragraphRecord `json:"copies"`
+}
+
+type paragraphRecord struct {
+	Path    string `json:"path"`
+	Line    int    `json:"line"`
+	EndLine int    `json:"endLine"`
+	Text    string `json:"text"`
+}
+
+func runDupes(command *Command, args []string, stdout, stderr io.Writer) int {
+	flags := newFlagSet(command, stderr)
+	opts := &options{}
+	opts.register(flags)
+	threshold := flags.Float64("threshold", analysis.DefaultDupeOptions.Threshold, "similarity from 0 to 1 at which paragraphs count as near-duplicates")
+	minWords := flags.Int("min-words", analysis.DefaultDupeOptions.MinWords, "ignore paragraphs shorter than this many words")
+	exact := flags.Bool("exact", false, "only report exact copies")
+	jsonOutput := flags.Bool("json", false, "print clusters as JSON")
+
+	if err := flags.Parse(args); err != nil {
+		return ExitError
+	}
+	if flags.NArg() != 0 {
+		flags.Usage()
+		return ExitError
+	}
+	if *threshold <= 0 || *threshold > 1 {
+		fmt.Fprintf(stderr, "rules-explorer: --threshold must be above 0 and at most 1\n")
+		return ExitError
+	}
+
+	explorer, err := opts.loadExplorer()
+	if err != nil {
+		fmt.Fprintf(stderr, "rules-explorer: %v\n", err)
+		return ExitError
+	}
+
+	clusters, err := analysis.FindDuplicates(context.Background(), explorer.GetAllFiles(), analysis.DupeOptions{
+		Threshold: *threshold,
+		MinWords:  *minWords,
+	})
+	if err != nil {
+		fmt.Fprintf(stderr, "rules-explorer: %v\n", err)
+		return ExitError
+	}
+	if *exact {
+		kept := clusters[:0]
+		for _, cluster := range clusters {
+			if cluster.Exact {
+				kept = append(kept, cluster)
+			}
+		}
+		clusters = kept
+	}
+
+	if *jsonOutput {
+		err = writeClustersJSON(stdout, clusters)
+	} else {
+		err = writeClusters(stdout, clusters)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "rules-explorer: %v\n", err)
+		return ExitError
+	}
+
+	if len(clusters) > 0 {
+		return ExitFindings
+	}
+	return ExitOK
+}
+
+func writeClustersJSON(w io.Writer, clusters []analysis.Cluster) error {
+	records := make([]clusterRecord, 0, len(clusters))
+	for _, cluster := range clusters {
+		record := clusterRecord{
+			Exact:      cluster.Exact,
+			Similarity: cluster.Similarity,
+			Files:      cluster.Files(),
+			Copies:     make([]paragraphRecord, 0, len(cluster.Paragraphs)),
+		}
+		for _, p := range cluster.Paragraphs {
+			record.Copies = append(record.Copies, paragraphRecord{Path: p.File.Path, Line: p.Line, EndLine: p.EndLine, Text: p.Text})
+		}
+		records = append(records, record)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(records)
+}
+
+func writeClusters(w io.Writer, clusters []analysis.Cluster) error {
+	if len(clusters) == 0 {
+		fmt.Fprintln(w, "No duplicates found")
+		return nil
+	}
+
+	for i, cluster := range clusters {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "%d. %s\n", i+1, cluster.Summary())
+		for _, p := range cluster.Paragraphs {
+			fmt.Fprintf(w, "   %s\n", p.Location())
+		}
+		preview := utils.GetContentPreview(strings.TrimSpace(cluster.Paragraphs[0].Text), 2, 100)
+		fmt.Fprintf(w, "   > %s\n", strings.ReplaceAll(preview, "\n", "\n   > "))
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"rules-explorer/internal/lint"
+)
+
+func init() {
+	register(&Command{
+		Name:    "lint",
+		Usage:   "lint [flags]",
+		Summary: "Check discovered files for common mistakes",
+		Run:     runLint,
+	})
+}
+
+func runLint(command *Command, args []string, stdout, stderr io.Writer) int {
+	flags := newFlagSet(command, stderr)
+	opts := &options{}
+	opts.register(flags)
+	format := flags.String("format", "text", "output format: text, json or sarif")
+	failOn := flags.String("fail-on", "error", "exit with 1 when a finding is at least this `severity`: info, warning or error")
+	maxSize := flags.Int("max-size", lint.DefaultMaxSize, "size in `bytes` above which a file is reported as oversized")
+	var disabled stringList
+	flags.Var(&disabled, "disable", "skip the check with this `id`; repeatable or comma-separated")
+	listChecks := flags.Bool("checks", false, "list the available checks and exit")
+
+	if err := flags.Parse(args); err != nil {
+		return ExitError
+	}
+	if flags.NArg() != 0 {
+		flags.Usage()
+		return ExitError
+	}
+	threshold, err := lint.ParseSeverity(*failOn)
+	if err != nil {
+		fmt.Fprintf(stderr, "rules-explorer: --fail-on: %v\n", err)
+		return ExitError
+	}
+
+	var write func(io.Writer, []lint.Finding) error
+	switch *format {
+	case "text":
+		write = lint.WriteText
+	case "json":
+		write = lint.WriteJSON
+	case "sarif":
+		write = lint.WriteSARIF
+	default:
+		fmt.Fprintf(stderr, "rules-explorer: unknown format %q (want text, json or sarif)\n", *format)
+		return ExitError
+	}
+
+	known := make(map[string]bool)
+	for _, check := range lint.Checks() {
+		known[check.ID] = true
+	}
+	if *listChecks {
+		for _, check := range lint.Checks() {
+			fmt.Fprintf(stdout, "%-24s %-8s %s\n", check.ID, check.Severity, check.Description)
+		}
+		return ExitOK
+	}
+	ids := make([]string, 0, len(disabled))
+	for _, value := range disabled {
+		for _, id := range strings.Split(value, ",") {
+			id = strings.TrimSpace(id)
+			if !known[id] {
+				fmt.Fprintf(stderr, "rules-explorer: --disable: unknown check %q\n", id)
+				return ExitError
+			}
+			ids = append(ids, id)
+		}
+	}
+
+	root, err := opts.rootDir()
+	if err != nil {
+		fmt.Fprintf(stderr, "rules-explorer: %v\n", err)
+		return ExitError
+	}
+	explorer, err := opts.loadExplorer()
+	if err != nil {
+		fmt.Fprintf(stderr, "rules-explorer: %v\n", err)
+		return ExitError
+	}
+
+	findings := lint.Run(explorer.GetAllFiles(), lint.Options{
+		Root:     root,
+		MaxSize:  *maxSize,
+		Disabled: ids,
+	})
+	if err := write(stdout, findings); err != nil {
+		fmt.Fprintf(stderr, "rules-explorer: %v\n", err)
+		return ExitError
+	}
+
+	for _, finding := range findings {
+		if finding.Severity >= threshold {
+			return ExitFindings
+		}
+	}
+	return ExitOK
+}
@@ -0,0 +1,227 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"rules-explorer/internal/core/resolver"
+	"rules-explorer/internal/core/tokens"
+	"rules-explorer/internal/core/types"
+	"rules-explorer/internal/utils"
+)
+
+func init() {
+	register(&Command{
+		Name:    "list",
+		Usage:   "list [flags]",
+		Summary: "List discovered rule and config files",
+		Run:     runList,
+	})
+}
+
+// fileRecord is the JSON form of a discovered file. Field names are part of
+// the command's output contract; add fields rather than renaming them.
+type fileRecord struct {
+	Path     string          `json:"path"`
+	Type     string          `json:"type"`
+	TypeName string          `json:"typeName"`
+	Size     int             `json:"size"`
+	Lines    int             `json:"lines"`
+	Tokens   int             `json:"tokens"`
+	Metadata *metadataRecord `json:"metadata,omitempty"`
+	// AlwaysApplied is only set by list
+	AlwaysApplied bool `json:"alwaysApplied,omitempty"`
+}
+
+type metadataRecord struct {
+	Description string   `json:"description,omitempty"`
+	Globs       []string `json:"globs,omitempty"`
+	AlwaysApply bool     `json:"alwaysApply"`
+	Mode        string   `json:"mode"`
+	// AllowedTools, Model and ArgumentHint are set for Claude commands,
+	// subagents and skills
+	AllowedTools []string          `json:"allowedTools,omitempty"`
+	Model        string            `json:"model,omitempty"`
+	ArgumentHint string            `json:"argumentHint,omitempty"`
+	Fields       map[string]string `json:"fields,omitempty"`
+	Error        string            `json:"error,omitempty"`
+}
+
+func newFileRecord(file types.FileItem) fileRecord {
+	record := fileRecord{
+		Path:     file.Path,
+		Type:     fileLabel(file),
+		TypeName: file.Type().String(),
+		Size:     len(file.Content),
+		Lines:    utils.CountLines(file.Content),
+		Tokens:   tokens.Count(file.Content),
+	}
+	if m := file.Metadata; m != nil {
+		record.Metadata = &metadataRecord{
+			Description:  m.Description,
+			Globs:        m.Globs,
+			AlwaysApply:  m.AlwaysApply,
+			Mode:         m.Mode().String(),
+			AllowedTools: m.AllowedTools,
+			Model:        m.Model,
+			ArgumentHint: m.ArgumentHint,
+			Fields:       m.Fields,
+			Error:        m.Error,
+		}
+	}
+	return record
+}
+
+// fileLabel is the pattern label, or the detected type's label for files
+// matched by unlabeled patterns
+func fileLabel(file types.FileItem) string {
+	if file.Label != "" {
+		return file.Label
+	}
+	return file.Type().Label()
+}
+
+// typeFilter keeps files whose label or type matches one of the values
+type typeFilter []string
+
+func (t typeFilter) match(file types.FileItem) bool {
+	if len(t) == 0 {
+		return true
+	}
+	for _, value := range t {
+		for _, label := range strings.Split(value, ",") {
+			label = strings.TrimSpace(label)
+			if label == fileLabel(file) || (types.ParseFileType(label) != types.Unknown && types.ParseFileType(label) == file.Type()) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func runList(command *Command, args []string, stdout, stderr io.Writer) int {
+	flags := newFlagSet(command, stderr)
+	opts := &options{}
+	opts.register(flags)
+	var fileTypes stringList
+	flags.Var(&fileTypes, "type", "only list files of this `type` (cursor, claude, ...); repeatable or comma-separated")
+	format := flags.String("format", "table", "output format: table, json or ndjson")
+	fileBudget := flags.Int("file-budget", -1, "warn about files estimated above this many `tokens` (0 turns it off; default from config)")
+	alwaysBudget := flags.Int("always-budget", -1, "warn when the always-applied files are estimated above this many `tokens` (0 turns it off; default from config)")
+
+	if err := flags.Parse(args); err != nil {
+		return ExitError
+	}
+	if flags.NArg() != 0 {
+		flags.Usage()
+		return ExitError
+	}
+	switch *format {
+	case "table", "json", "ndjson":
+	default:
+		fmt.Fprintf(stderr, "rules-explorer: unknown format %q (want table, json or ndjson)\n", *format)
+		return ExitError
+	}
+
+	explorer, err := opts.loadExplorer()
+	if err != nil {
+		fmt.Fprintf(stderr, "rules-explorer: %v\n", err)
+		return ExitError
+	}
+
+	budget := opts.settings.Budget()
+	if *fileBudget >= 0 {
+		budget.File = *fileBudget
+	}
+	if *alwaysBudget >= 0 {
+		budget.Always = *alwaysBudget
+	}
+
+	root, err := opts.rootDir()
+	if err != nil {
+		fmt.Fprintf(stderr, "rules-explorer: %v\n", err)
+		return ExitError
+	}
+	always := resolver.AlwaysApplied(root, explorer.GetAllFiles())
+	alwaysPaths := make(map[string]bool, len(always))
+	alwaysTokens := 0
+	for _, file := range always {
+		alwaysPaths[file.Path] = true
+		alwaysTokens += tokens.Count(file.Content)
+	}
+
+	filter := typeFilter(fileTypes)
+	records := make([]fileRecord, 0)
+	for _, file := range explorer.GetAllFiles() {
+		if filter.match(file) {
+			record := newFileRecord(file)
+			record.AlwaysApplied = alwaysPaths[file.Path]
+			records = append(records, record)
+			if budget.FileExceeded(record.Tokens) {
+				fmt.Fprintf(stderr, "rules-explorer: warning: %s is ~%s tokens, over the %s per-file budget\n",
+					file.Path, tokens.Format(record.Tokens), tokens.Format(budget.File))
+			}
+		}
+	}
+	if budget.AlwaysExceeded(alwaysTokens) {
+		fmt.Fprintf(stderr, "rules-explorer: warning: the %d always-applied files are ~%s tokens, over the %s budget\n",
+			len(always), tokens.Format(alwaysTokens), tokens.Format(budget.Always))
+	}
+
+	if err := writeRecords(stdout, *format, records); err != nil {
+		fmt.Fprintf(stderr, "rules-explorer: %v\n", err)
+		return ExitError
+	}
+	if *format == "table" && len(records) > 0 {
+		total := 0
+		for _, record := range records {
+			total += record.Tokens
+		}
+		fmt.Fprintf(stdout, "\n%d files, ~%s tokens; always applied: %d files with imports, ~%s tokens\n",
+			len(records), tokens.Format(total), len(always), tokens.Format(alwaysTokens))
+	}
+	if len(records) == 0 {
+		return ExitNoMatch
+	}
+	return ExitOK
+}
+
+func writeRecords(w io.Writer, format string, records []fileRecord) error {
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(records)
+	case "ndjson":
+		encoder := json.NewEncoder(w)
+		for _, record := range records {
+			if err := encoder.Encode(record); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if len(records) == 0 {
+		fmt.Fprintln(w, "No files found")
+		return nil
+	}
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "PATH\tTYPE\tSIZE\tLINES\tTOKENS\tMODE\tDESCRIPTION")
+	for _, record := range records {
+		mode, description := "", ""
+		if record.Metadata != nil {
+			mode = record.Metadata.Mode
+			description = record.Metadata.Description
+			if record.Metadata.Error != "" {
+				description = "invalid frontmatter: " + record.Metadata.Error
+			}
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\t%s\t%s\n", record.Path, record.Type,
+			utils.FormatFileSize(record.Size), record.Lines, tokens.Format(record.Tokens), mode, description)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,230 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"rules-explorer/internal/core/search"
+	"rules-explorer/internal/core/types"
+)
+
+func init() {
+	register(&Command{
+		Name:    "search",
+		Usage:   "search [flags] [--] <query>",
+		Summary: "Search discovered files with the TUI query syntax",
+		Run:     runSearch,
+	})
+}
+
+const (
+	ansiReset = "\x1b[0m"
+	ansiPath  = "\x1b[35m"
+	ansiLine  = "\x1b[32m"
+	ansiMatch = "\x1b[1;31m"
+	ansiDim   = "\x1b[2m"
+)
+
+type searchRecord struct {
+	fileRecord
+	Score int `json:"score"`
+}
+
+type lineRecord struct {
+	Path   string       `json:"path"`
+	Line   int          `json:"line"`
+	Column int          `json:"column"`
+	Text   string       `json:"text"`
+	Spans  []types.Span `json:"spans"`
+	Before []string     `json:"before,omitempty"`
+	After  []string     `json:"after,omitempty"`
+}
+
+func runSearch(command *Command, args []string, stdout, stderr io.Writer) int {
+	flags := newFlagSet(command, stderr)
+	opts := &options{}
+	opts.register(flags)
+	lines := flags.Bool("lines", false, "print matching lines instead of files")
+	context := flags.Int("context", 0, "lines of context around each match (with --lines)")
+	regex := flags.Bool("regex", false, "treat the whole query as one regular expression")
+	jsonOutput := flags.Bool("json", false, "print results as JSON")
+	colorMode := flags.String("color", "auto", "highlight matches: auto, always or never")
+
+	if err := flags.Parse(args); err != nil {
+		return ExitError
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return ExitError
+	}
+	color, err := useColor(*colorMode, stdout)
+	if err != nil {
+		fmt.Fprintf(stderr, "rules-explorer: %v\n", err)
+		return ExitError
+	}
+
+	filter := search.NewFilter()
+	filter.SetRegexMode(*regex)
+	if err := filter.SetQuery(strings.Join(flags.Args(), " ")); err != nil {
+		fmt.Fprintf(stderr, "rules-explorer: invalid query: %v\n", err)
+		return ExitError
+	}
+
+	explorer, err := opts.loadExplorer()
+	if err != nil {
+		fmt.Fprintf(stderr, "rules-explorer: %v\n", err)
+		return ExitError
+	}
+	// Frontmatter keys are only fields once the files are known
+	if err := filter.SetFields(search.FieldNames(explorer.GetAllFiles())); err != nil {
+		fmt.Fprintf(stderr, "rules-explorer: invalid query: %v\n", err)
+		return ExitError
+	}
+
+	found := 0
+	if *lines {
+		matches := filter.GrepLines(explorer.GetAllFiles(), max(*context, 0))
+		found = len(matches)
+		err = writeLineMatches(stdout, matches, *jsonOutput, color)
+	} else {
+		results := filter.Rank(explorer.GetAllFiles())
+		found = len(results)
+		err = writeSearchResults(stdout, results, *jsonOutput, color)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "rules-explorer: %v\n", err)
+		return ExitError
+	}
+
+	if found == 0 {
+		return ExitNoMatch
+	}
+	return ExitOK
+}
+
+// useColor resolves --color; auto colors only terminals, and honours NO_COLOR
+func useColor(mode string, stdout io.Writer) (bool, error) {
+	switch mode {
+	case "always":
+		return true, nil
+	case "never":
+		return false, nil
+	case "auto":
+		if os.Getenv("NO_COLOR") != "" {
+			return false, nil
+		}
+		f, ok := stdout.(*os.File)
+		if !ok {
+			return false, nil
+		}
+		info, err := f.Stat()
+		return err == nil && info.Mode()&os.ModeCharDevice != 0, nil
+	}
+	return false, fmt.Errorf("unknown color mode %q (want auto, always or never)", mode)
+}
+
+func writeSearchResults(w io.Writer, results []search.Result, asJSON, color bool) error {
+	if asJSON {
+		records := make([]searchRecord, 0, len(results))
+		for _, result := range results {
+			records = append(records, searchRecord{fileRecord: newFileRecord(result.File), Score: result.Score})
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(records)
+	}
+
+	for _, result := range results {
+		if color {
+			fmt.Fprintf(w, "%s%s%s\n", ansiPath, result.File.Path, ansiReset)
+		} else {
+			fmt.Fprintln(w, result.File.Path)
+		}
+	}
+	return nil
+}
+
+// writeLineMatches prints grep-style output: path:line:column: text for
+// matches, path-line- text for context and -- between separate groups
+func writeLineMatches(w io.Writer, matches []search.LineMatch, asJSON, color bool) error {
+	if asJSON {
+		records := make([]lineRecord, 0, len(matches))
+		for _, match := range matches {
+			records = append(records, lineRecord{
+				Path:   match.File.Path,
+				Line:   match.Line,
+				Column: match.Column,
+				Text:   match.Text,
+				Spans:  match.Spans,
+				Before: match.Before,
+				After:  match.After,
+			})
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(records)
+	}
+
+	paint := func(code, text string) string {
+		if !color {
+			return text
+		}
+		return code + text + ansiReset
+	}
+
+	lastPath, lastLine := "", 0
+	for i, match := range matches {
+		path := match.File.Path
+		first := match.Line - len(match.Before)
+		hasContext := len(match.Before) > 0 || len(match.After) > 0
+
+		if hasContext && lastPath != "" && (path != lastPath || first > lastLine+1) {
+			fmt.Fprintln(w, paint(ansiDim, "--"))
+		}
+		for j, text := range match.Before {
+			if path == lastPath && first+j <= lastLine {
+				continue
+			}
+			fmt.Fprintf(w, "%s-%s- %s\n", paint(ansiPath, path), paint(ansiLine, fmt.Sprint(first+j)), text)
+		}
+		fmt.Fprintf(w, "%s:%s:%d: %s\n", paint(ansiPath, path), paint(ansiLine, fmt.Sprint(match.Line)),
+			match.Column, highlightLine(match, color))
+
+		// Context stops short of the next match so that line prints as a match
+		after := match.After
+		if i+1 < len(matches) && matches[i+1].File.Path == path {
+			if gap := matches[i+1].Line - match.Line - 1; gap < len(after) {
+				after = after[:gap]
+			}
+		}
+		for j, text := range after {
+			fmt.Fprintf(w, "%s-%s- %s\n", paint(ansiPath, path), paint(ansiLine, fmt.Sprint(match.Line+1+j)), text)
+		}
+
+		lastPath = path
+		lastLine = match.Line + len(after)
+	}
+	return nil
+}
+
+func highlightLine(match search.LineMatch, color bool) string {
+	if !color {
+		return match.Text
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, span := range match.Spans {
+		if span.Start < last || span.End > len(match.Text) {
+			continue
+		}
+		b.WriteString(match.Text[last:span.Start])
+		b.WriteString(ansiMatch + match.Text[span.Start:span.End] + ansiReset)
+		last = span.End
+	}
+	b.WriteString(match.Text[last:])
+	return b.String()
+}
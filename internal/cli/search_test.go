@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSearchNegatedQuery(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", "")
+	for name, content := range map[string]string{
+		"CLAUDE.md":                 "Use the new API.",
+		".cursor/rules/legacy.mdc":  "---\ndescription: old\n---\nThe v1 API is deprecated.",
+		".cursor/rules/current.mdc": "---\ndescription: new\n---\nPrefer the v2 API.",
+	} {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	command, _ := Lookup("search")
+
+	tests := []struct {
+		args []string
+		code int
+		want string
+	}{
+		{[]string{"--", "-deprecated"}, ExitOK, ".cursor/rules/current.mdc\nCLAUDE.md\n"},
+		{[]string{"--", "-deprecated", "type:cursor"}, ExitOK, ".cursor/rules/current.mdc\n"},
+		{[]string{"api", "-deprecated", "-prefer"}, ExitOK, "CLAUDE.md\n"},
+		{[]string{"--", "-api"}, ExitNoMatch, ""},
+		// without -- a leading negation reads as an unknown flag
+		{[]string{"-deprecated"}, ExitError, ""},
+	}
+
+	for _, tt := range tests {
+		var stdout, stderr bytes.Buffer
+		args := append([]string{"--root", root, "--color", "never"}, tt.args...)
+		code := command.Run(command, args, &stdout, &stderr)
+		if code != tt.code || stdout.String() != tt.want {
+			t.Errorf("search %v = %d %q, want %d %q (stderr %q)", tt.args, code, stdout.String(), tt.code, tt.want, stderr.String())
+		}
+	}
+}
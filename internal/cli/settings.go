@@ -0,0 +1,187 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"rules-explorer/internal/core/settings"
+)
+
+func init() {
+	register(&Command{
+		Name:    "settings",
+		Usage:   "settings [flags]",
+		Summary: "Show the effective Claude Code settings merged from every scope",
+		Run:     runSettings,
+	})
+}
+
+type settingsFileRecord struct {
+	Path  string         `json:"path"`
+	Scope settings.Scope `json:"scope"`
+	Error string         `json:"error,omitempty"`
+}
+
+type settingsRecord struct {
+	Files     []settingsFileRecord `json:"files"`
+	Effective settings.Effective   `json:"effective"`
+}
+
+func runSettings(command *Command, args []string, stdout, stderr io.Writer) int {
+	flags := newFlagSet(command, stderr)
+	opts := &options{}
+	opts.register(flags)
+	jsonOutput := flags.Bool("json", false, "print the files and effective settings as JSON")
+
+	if err := flags.Parse(args); err != nil {
+		return ExitError
+	}
+	if flags.NArg() != 0 {
+		flags.Usage()
+		return ExitError
+	}
+
+	root, err := opts.rootDir()
+	if err != nil {
+		fmt.Fprintf(stderr, "rules-explorer: %v\n", err)
+		return ExitError
+	}
+	files, err := settings.Load(root)
+	if err != nil {
+		fmt.Fprintf(stderr, "rules-explorer: %v\n", err)
+		return ExitError
+	}
+
+	status := ExitOK
+	for _, file := range files {
+		if file.Err != nil {
+			fmt.Fprintf(stderr, "rules-explorer: %s: %v\n", file.DisplayPath, file.Err)
+			status = ExitError
+		}
+	}
+	effective := settings.Merge(files)
+
+	if *jsonOutput {
+		err = writeSettingsJSON(stdout, files, effective)
+	} else {
+		err = writeSettings(stdout, files, effective)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "rules-explorer: %v\n", err)
+		return ExitError
+	}
+
+	if status == ExitOK && len(files) == 0 {
+		return ExitNoMatch
+	}
+	return status
+}
+
+func writeSettingsJSON(w io.Writer, files []settings.File, effective settings.Effective) error {
+	record := settingsRecord{Files: make([]settingsFileRecord, 0, len(files)), Effective: effective}
+	for _, file := range files {
+		fileRecord := settingsFileRecord{Path: file.DisplayPath, Scope: file.Scope}
+		if file.Err != nil {
+			fileRecord.Error = file.Err.Error()
+		}
+		record.Files = append(record.Files, fileRecord)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(record)
+}
+
+func writeSettings(w io.Writer, files []settings.File, effective settings.Effective) error {
+	if len(files) == 0 {
+		fmt.Fprintln(w, "No settings files found")
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "Files (lowest precedence first):")
+	for _, file := range files {
+		note := ""
+		if file.Err != nil {
+			note = "\tnot parsed"
+		}
+		fmt.Fprintf(tw, "  %s\t%s%s\n", file.Scope, file.DisplayPath, note)
+	}
+
+	if effective.Empty() {
+		fmt.Fprintln(tw, "\nNothing is set")
+		return tw.Flush()
+	}
+
+	if len(effective.Allow)+len(effective.Deny)+len(effective.Ask)+len(effective.Directories) > 0 ||
+		effective.DefaultMode.Value != "" {
+		fmt.Fprintln(tw, "\nPermissions:")
+		for _, list := range []struct {
+			name   string
+			values []settings.Value
+		}{
+			{"allow", effective.Allow},
+			{"ask", effective.Ask},
+			{"deny", effective.Deny},
+			{"directory", effective.Directories},
+		} {
+			for _, v := range list.values {
+				fmt.Fprintf(tw, "  %s\t%s\t%s\n", list.name, v.Value, scopeNote(v))
+			}
+		}
+		if effective.DefaultMode.Value != "" {
+			fmt.Fprintf(tw, "  default mode\t%s\t%s\n", effective.DefaultMode.Value, scopeNote(effective.DefaultMode))
+		}
+	}
+
+	if effective.Model.Value != "" {
+		fmt.Fprintln(tw, "\nModel:")
+		fmt.Fprintf(tw, "  model\t%s\t%s\n", effective.Model.Value, scopeNote(effective.Model))
+	}
+
+	if len(effective.Env) > 0 {
+		fmt.Fprintln(tw, "\nEnvironment:")
+		for _, v := range effective.Env {
+			fmt.Fprintf(tw, "  %s\t%s\t%s\n", v.Name, v.Value.Value, scopeNote(v.Value))
+		}
+	}
+
+	if len(effective.Hooks) > 0 {
+		fmt.Fprintln(tw, "\nHooks:")
+		for _, hook := range effective.Hooks {
+			matcher := hook.Matcher
+			if matcher == "" {
+				matcher = "*"
+			}
+			fmt.Fprintf(tw, "  %s\t%s\t%s\t[%s]\n", hook.Event, matcher, hookCommand(hook.Hook), hook.Scope)
+		}
+	}
+
+	return tw.Flush()
+}
+
+// scopeNote marks where a value comes from, and which scopes it overrides
+func scopeNote(v settings.Value) string {
+	if len(v.Overrides) == 0 {
+		return "[" + v.Scope.String() + "]"
+	}
+	overridden := make([]string, 0, len(v.Overrides))
+	for _, scope := range v.Overrides {
+		overridden = append(overridden, scope.String())
+	}
+	return fmt.Sprintf("[%s, overrides %s]", v.Scope, strings.Join(overridden, ", "))
+}
+
+func hookCommand(hook settings.Hook) string {
+	command := hook.Command
+	if hook.Type != "" && hook.Type != "command" {
+		command = hook.Type + ": " + command
+	}
+	if hook.Timeout > 0 {
+		command += fmt.Sprintf(" (timeout %ds)", hook.Timeout)
+	}
+	return command
+}
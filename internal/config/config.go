@@ -0,0 +1,152 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"rules-explorer/internal/core/glob"
+	"rules-explorer/internal/core/patterns"
+	"rules-explorer/internal/core/tokens"
+)
+
+const FileName = ".rules-explorer.json"
+
+var ErrNoPatterns = errors.New("no discovery patterns configured")
+
+// File is the on-disk configuration. It is looked up in the project root
+// first and then in the user's config directory.
+type File struct {
+	// UseDefaults keeps the built-in patterns alongside the configured ones.
+	// It defaults to true when omitted.
+	UseDefaults *bool              `json:"useDefaults,omitempty"`
+	Patterns    []patterns.Pattern `json:"patterns,omitempty"`
+	Exclude     []string           `json:"exclude,omitempty"`
+	Tokens      *TokenSettings     `json:"tokens,omitempty"`
+
+	path string
+}
+
+// TokenSettings selects the token estimator and the budgets above which
+// counts are flagged. Omitted budgets use the defaults; 0 turns a warning off.
+type TokenSettings struct {
+	Estimator    string `json:"estimator,omitempty"`
+	FileBudget   *int   `json:"fileBudget,omitempty"`
+	AlwaysBudget *int   `json:"alwaysBudget,omitempty"`
+}
+
+func Default() *File {
+	return &File{}
+}
+
+// Load reads the config file at path, or searches for one when path is empty.
+// A missing config is not an error and yields the defaults.
+func Load(path, root string) (*File, error) {
+	if path != "" {
+		return readFile(path)
+	}
+
+	for _, candidate := range searchPaths(root) {
+		if _, err := os.Stat(candidate); err == nil {
+			return readFile(candidate)
+		}
+	}
+
+	return Default(), nil
+}
+
+func searchPaths(root string) []string {
+	paths := []string{filepath.Join(root, FileName)}
+	if dir, err := os.UserConfigDir(); err == nil {
+		paths = append(paths, filepath.Join(dir, "rules-explorer", "config.json"))
+	}
+	return paths
+}
+
+func readFile(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	file := Default()
+	if err := json.Unmarshal(data, file); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	file.path = path
+
+	if err := file.validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return file, nil
+}
+
+func (f *File) validate() error {
+	if f.UseDefaults != nil && !*f.UseDefaults && len(f.Patterns) == 0 {
+		return ErrNoPatterns
+	}
+	for i, pattern := range f.Patterns {
+		if len(pattern.Include) == 0 {
+			return fmt.Errorf("pattern %d (%s) has no include globs", i, pattern.Name)
+		}
+		for _, g := range append(pattern.Include, pattern.Exclude...) {
+			if err := glob.Validate(g); err != nil {
+				return fmt.Errorf("pattern %d (%s): %q: %w", i, pattern.Name, g, err)
+			}
+		}
+	}
+	for _, g := range f.Exclude {
+		if err := glob.Validate(g); err != nil {
+			return fmt.Errorf("exclude %q: %w", g, err)
+		}
+	}
+	if t := f.Tokens; t != nil {
+		if _, ok := tokens.Lookup(t.Estimator); t.Estimator != "" && !ok {
+			return fmt.Errorf("unknown token estimator %q (want one of %v)", t.Estimator, tokens.Names())
+		}
+		if (t.FileBudget != nil && *t.FileBudget < 0) || (t.AlwaysBudget != nil && *t.AlwaysBudget < 0) {
+			return errors.New("token budgets can't be negative")
+		}
+	}
+	return nil
+}
+
+// Path returns the file the config was read from, or "" for the defaults.
+func (f *File) Path() string {
+	return f.path
+}
+
+func (f *File) Registry() *patterns.Registry {
+	list := make([]patterns.Pattern, 0)
+	list = append(list, f.Patterns...)
+	if f.UseDefaults == nil || *f.UseDefaults {
+		list = append(list, patterns.DefaultPatterns()...)
+	}
+
+	return patterns.NewRegistry(list, f.Exclude)
+}
+
+// Estimator returns the configured token estimator name
+func (f *File) Estimator() string {
+	if f.Tokens == nil || f.Tokens.Estimator == "" {
+		return tokens.DefaultEstimator
+	}
+	return f.Tokens.Estimator
+}
+
+func (f *File) Budget() tokens.Budget {
+	budget := tokens.DefaultBudget
+	if f.Tokens == nil {
+		return budget
+	}
+	if f.Tokens.FileBudget != nil {
+		budget.File = *f.Tokens.FileBudget
+	}
+	if f.Tokens.AlwaysBudget != nil {
+		budget.Always = *f.Tokens.AlwaysBudget
+	}
+	return budget
+}
@@ -0,0 +1,210 @@
+package claudemd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"rules-explorer/internal/core/ignore"
+)
+
+type Scope int
+
+const (
+	ScopeUser Scope = iota
+	ScopeAncestor
+	ScopeTarget
+	ScopeDescendant
+)
+
+func (s Scope) String() string {
+	switch s {
+	case ScopeUser:
+		return "user"
+	case ScopeAncestor:
+		return "ancestor"
+	case ScopeTarget:
+		return "directory"
+	case ScopeDescendant:
+		return "on demand"
+	default:
+		return "unknown"
+	}
+}
+
+// Entry is one memory file in the effective chain.
+type Entry struct {
+	// Path is absolute; DisplayPath is relative to the project root when the
+	// file is inside it and home-relative for user memory
+	Path        string
+	DisplayPath string
+	Scope       Scope
+	Local       bool
+	Content     string
+}
+
+// OnDemand reports whether the file is only loaded once Claude reads files in
+// its subtree.
+func (e Entry) OnDemand() bool {
+	return e.Scope == ScopeDescendant
+}
+
+var memoryNames = []string{
+	"CLAUDE.md",
+	filepath.Join(".claude", "CLAUDE.md"),
+	"CLAUDE.local.md",
+}
+
+// Hierarchy returns the memory files Claude Code would load when started in
+// target, ordered from lowest to highest precedence: user memory, then every
+// ancestor directory from the filesystem root down to target, then
+// CLAUDE.md files in subdirectories which are only read on demand.
+//
+// The subdirectory walk skips the default skip list and, when matcher is not
+// nil, directories it ignores. Cancelling ctx stops the walk and returns
+// ctx's error.
+func Hierarchy(ctx context.Context, root, target string, matcher *ignore.Matcher) ([]Entry, error) {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(root, target)
+	}
+	target = filepath.Clean(target)
+
+	info, err := os.Stat(target)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		target = filepath.Dir(target)
+	}
+
+	entries := make([]Entry, 0)
+	// For a project under $HOME the user memory is also an ancestor's
+	// .claude/CLAUDE.md; it is only listed once, as user memory
+	seen := make(map[string]bool)
+	add := func(entry Entry) {
+		if !seen[entry.Path] {
+			seen[entry.Path] = true
+			entries = append(entries, entry)
+		}
+	}
+
+	if home, err := os.UserHomeDir(); err == nil {
+		userMemory := filepath.Join(home, ".claude", "CLAUDE.md")
+		if entry, ok := readEntry(userMemory, root, ScopeUser); ok {
+			entry.DisplayPath = "~/.claude/CLAUDE.md"
+			add(entry)
+		}
+	}
+
+	// Collect ancestors up to, but not including, the filesystem root
+	dirs := make([]string, 0)
+	for dir := target; ; {
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dirs = append(dirs, dir)
+		dir = parent
+	}
+
+	for i := len(dirs) - 1; i >= 0; i-- {
+		scope := ScopeAncestor
+		if dirs[i] == target {
+			scope = ScopeTarget
+		}
+		for _, name := range memoryNames {
+			if entry, ok := readEntry(filepath.Join(dirs[i], name), root, scope); ok {
+				add(entry)
+			}
+		}
+	}
+
+	onDemand, err := descendants(ctx, target, root, matcher)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range onDemand {
+		add(entry)
+	}
+
+	return entries, nil
+}
+
+func descendants(ctx context.Context, target, root string, matcher *ignore.Matcher) ([]Entry, error) {
+	entries := make([]Entry, 0)
+
+	err := filepath.WalkDir(target, func(path string, d os.DirEntry, err error) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if path != target && skipDir(path, d.Name(), root, matcher) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Dir(path) == target || filepath.Dir(path) == filepath.Join(target, ".claude") {
+			return nil
+		}
+		if d.Name() == "CLAUDE.md" || d.Name() == "CLAUDE.local.md" {
+			if entry, ok := readEntry(path, root, ScopeDescendant); ok {
+				entries = append(entries, entry)
+			}
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Path < entries[j].Path
+	})
+	return entries, nil
+}
+
+// skipDir reports whether the walk should prune dir. The matcher only knows
+// paths below root.
+func skipDir(dir, name, root string, matcher *ignore.Matcher) bool {
+	if name == ".git" || ignore.IsDefaultSkipDir(name) {
+		return true
+	}
+	if matcher == nil {
+		return false
+	}
+	rel, err := filepath.Rel(root, dir)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return false
+	}
+	return matcher.Match(rel, true)
+}
+
+func readEntry(path, root string, scope Scope) (Entry, bool) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return Entry{}, false
+	}
+
+	display := path
+	if rel, err := filepath.Rel(root, path); err == nil && !strings.HasPrefix(rel, "..") {
+		display = rel
+	}
+
+	return Entry{
+		Path:        path,
+		DisplayPath: display,
+		Scope:       scope,
+		Local:       filepath.Base(path) == "CLAUDE.local.md",
+		Content:     string(content),
+	}, true
+}
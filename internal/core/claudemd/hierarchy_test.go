@@ -0,0 +1,122 @@
+package claudemd
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"rules-explorer/internal/core/ignore"
+)
+
+func writeFiles(t *testing.T, dir string, names ...string) {
+	t.Helper()
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(name), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestHierarchy(t *testing.T) {
+	tmp := t.TempDir()
+	home := filepath.Join(tmp, "home")
+	root := filepath.Join(home, "proj")
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(tmp, "config"))
+	writeFiles(t, home,
+		".claude/CLAUDE.md",
+		"CLAUDE.md",
+		"proj/CLAUDE.md",
+		"proj/.claude/CLAUDE.md",
+		"proj/CLAUDE.local.md",
+		"proj/pkg/CLAUDE.md",
+		"proj/pkg/api/CLAUDE.md",
+		"proj/node_modules/dep/CLAUDE.md",
+		"proj/build/CLAUDE.md",
+		"proj/pkg/CLAUDE.local.md",
+	)
+	if err := os.Mkdir(filepath.Join(root, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	// Ignored directories are pruned, but local memory is meant to be ignored
+	// and is still found
+	if err := os.WriteFile(filepath.Join(root, ".gitignore"), []byte("build/\nCLAUDE.local.md\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		target string
+		want   []string
+	}{
+		{".", []string{
+			"~/.claude/CLAUDE.md user",
+			"home/CLAUDE.md ancestor",
+			"CLAUDE.md directory",
+			".claude/CLAUDE.md directory",
+			"CLAUDE.local.md directory",
+			"pkg/CLAUDE.local.md on demand",
+			"pkg/CLAUDE.md on demand",
+			"pkg/api/CLAUDE.md on demand",
+		}},
+		{"pkg", []string{
+			"~/.claude/CLAUDE.md user",
+			"home/CLAUDE.md ancestor",
+			"CLAUDE.md ancestor",
+			".claude/CLAUDE.md ancestor",
+			"CLAUDE.local.md ancestor",
+			"pkg/CLAUDE.md directory",
+			"pkg/CLAUDE.local.md directory",
+			"pkg/api/CLAUDE.md on demand",
+		}},
+		// a file target starts from its directory
+		{"pkg/api/CLAUDE.md", []string{
+			"~/.claude/CLAUDE.md user",
+			"home/CLAUDE.md ancestor",
+			"CLAUDE.md ancestor",
+			".claude/CLAUDE.md ancestor",
+			"CLAUDE.local.md ancestor",
+			"pkg/CLAUDE.md ancestor",
+			"pkg/CLAUDE.local.md ancestor",
+			"pkg/api/CLAUDE.md directory",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			entries, err := Hierarchy(context.Background(), root, tt.target, ignore.New(root))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := make([]string, 0, len(entries))
+			for _, entry := range entries {
+				// Skip memory files that happen to exist above the test's tree
+				if !strings.HasPrefix(entry.Path, tmp) {
+					continue
+				}
+				display := strings.TrimPrefix(entry.DisplayPath, tmp+string(filepath.Separator))
+				got = append(got, display+" "+entry.Scope.String())
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("Hierarchy(%q) =\n%s\nwant\n%s", tt.target, strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestHierarchyCancelled(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, "CLAUDE.md", "pkg/CLAUDE.md")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := Hierarchy(ctx, root, ".", nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("Hierarchy with a cancelled context = %v, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,177 @@
+package claudemd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// MaxImportDepth mirrors the recursion limit Claude Code applies to imports
+const MaxImportDepth = 5
+
+// Reference is an "@path" token found in a memory file.
+type Reference struct {
+	Raw    string
+	Target string
+	Line   int
+	Column int
+}
+
+// Import is a resolved reference and the imports of the file it points to.
+type Import struct {
+	Reference
+	// Path is the absolute path the reference resolves to
+	Path          string
+	Missing       bool
+	Cycle         bool
+	DepthExceeded bool
+	Children      []*Import
+}
+
+// Broken reports whether the import or any of its descendants cannot be loaded.
+func (i *Import) Broken() bool {
+	if i.Missing {
+		return true
+	}
+	for _, child := range i.Children {
+		if child.Broken() {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseImports finds "@path" references outside of code spans and fenced code
+// blocks. A reference must start a line or follow whitespace, which rules out
+// e-mail addresses and decorators in prose.
+func ParseImports(content string) []Reference {
+	references := make([]Reference, 0)
+	inFence := false
+	fence := ""
+
+	for i, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			marker := trimmed[:3]
+			if !inFence {
+				inFence, fence = true, marker
+			} else if marker == fence {
+				inFence = false
+			}
+			continue
+		}
+		if inFence {
+			continue
+		}
+
+		masked := maskCodeSpans(line)
+		for col := 0; col < len(masked); col++ {
+			if masked[col] != '@' || (col > 0 && !isSpace(masked[col-1])) {
+				continue
+			}
+			end := col + 1
+			for end < len(masked) && !isSpace(masked[end]) {
+				end++
+			}
+			target := strings.TrimRight(masked[col+1:end], ".,;:!?)]\"'")
+			if looksLikePath(target) {
+				references = append(references, Reference{
+					Raw:    "@" + target,
+					Target: target,
+					Line:   i + 1,
+					Column: col + 1,
+				})
+			}
+			col = end
+		}
+	}
+
+	return references
+}
+
+// ResolveImports builds the import tree for the memory file at path.
+func ResolveImports(path string, content string) []*Import {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		abs = path
+	}
+	return resolve(abs, content, map[string]bool{abs: true}, 1)
+}
+
+func resolve(path string, content string, visiting map[string]bool, depth int) []*Import {
+	imports := make([]*Import, 0)
+
+	for _, reference := range ParseImports(content) {
+		node := &Import{
+			Reference: reference,
+			Path:      ResolvePath(path, reference.Target),
+		}
+		imports = append(imports, node)
+
+		if visiting[node.Path] {
+			node.Cycle = true
+			continue
+		}
+
+		data, err := os.ReadFile(node.Path)
+		if err != nil {
+			node.Missing = true
+			continue
+		}
+
+		if depth >= MaxImportDepth {
+			node.DepthExceeded = len(ParseImports(string(data))) > 0
+			continue
+		}
+
+		visiting[node.Path] = true
+		node.Children = resolve(node.Path, string(data), visiting, depth+1)
+		delete(visiting, node.Path)
+	}
+
+	return imports
+}
+
+// ResolvePath resolves an import target relative to the importing file.
+// "~/" targets are relative to the user's home directory.
+func ResolvePath(from string, target string) string {
+	if strings.HasPrefix(target, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, filepath.FromSlash(target[2:]))
+		}
+	}
+	if filepath.IsAbs(target) {
+		return filepath.Clean(target)
+	}
+	return filepath.Join(filepath.Dir(from), filepath.FromSlash(target))
+}
+
+func maskCodeSpans(line string) string {
+	masked := []byte(line)
+	inSpan := false
+	for i := range masked {
+		if masked[i] == '`' {
+			inSpan = !inSpan
+			continue
+		}
+		if inSpan {
+			masked[i] = ' '
+		}
+	}
+	return string(masked)
+}
+
+func looksLikePath(target string) bool {
+	if target == "" {
+		return false
+	}
+	if strings.HasPrefix(target, "~/") || strings.HasPrefix(target, "/") ||
+		strings.HasPrefix(target, "./") || strings.HasPrefix(target, "../") {
+		return true
+	}
+	return strings.Contains(target, "/") || strings.Contains(target, ".")
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r'
+}
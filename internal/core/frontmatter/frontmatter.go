@@ -0,0 +1,282 @@
+package frontmatter
+
+import (
+	"fmt"
+	"strings"
+
+	"rules-explorer/internal/core/types"
+)
+
+// Value is a frontmatter value. Lists keep their items; scalars have a single
+// unquoted string. Raw is the text of an inline list as written.
+type Value struct {
+	Scalar string
+	List   []string
+	IsList bool
+	Raw    string
+}
+
+// String joins list items with ", " so that raw values can be displayed and
+// searched uniformly.
+func (v Value) String() string {
+	if v.IsList {
+		return strings.Join(v.List, ", ")
+	}
+	return v.Scalar
+}
+
+// Block is a parsed frontmatter block.
+type Block struct {
+	Keys   []string
+	Fields map[string]Value
+	// Lines is the number of lines occupied by the block including both
+	// delimiters, so the body starts at line Lines+1.
+	Lines int
+}
+
+// Split returns the raw frontmatter between the leading "---" delimiters and
+// the remaining body. ok is false when the content has no frontmatter.
+func Split(content string) (raw string, body string, ok bool, err error) {
+	content = strings.TrimPrefix(content, "\ufeff")
+	if !strings.HasPrefix(content, "---\n") && !strings.HasPrefix(content, "---\r\n") {
+		return "", content, false, nil
+	}
+
+	lines := strings.SplitAfter(content, "\n")
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimRight(lines[i], "\r\n") == "---" {
+			return strings.Join(lines[1:i], ""), strings.Join(lines[i+1:], ""), true, nil
+		}
+	}
+	return "", content, true, fmt.Errorf("frontmatter is not closed with ---")
+}
+
+// Parse reads the subset of YAML used by rule frontmatter: "key: value"
+// scalars, inline "[a, b]" lists and block "- item" lists. Values are kept as
+// strings; unknown syntax is reported as an error with its line number.
+func Parse(content string) (*Block, string, bool, error) {
+	raw, body, ok, err := Split(content)
+	if !ok || err != nil {
+		return nil, body, ok, err
+	}
+
+	block := &Block{
+		Fields: make(map[string]Value),
+		Lines:  strings.Count(raw, "\n") + 2,
+	}
+
+	currentKey := ""
+	for i, line := range strings.Split(strings.TrimRight(raw, "\r\n"), "\n") {
+		lineNo := i + 2
+		line = strings.TrimRight(line, "\r")
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "- ") || trimmed == "-" {
+			if currentKey == "" {
+				return block, body, true, fmt.Errorf("line %d: list item without a key", lineNo)
+			}
+			item, err := unquote(strings.TrimSpace(strings.TrimPrefix(trimmed, "-")))
+			if err != nil {
+				return block, body, true, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+			value := block.Fields[currentKey]
+			value.IsList = true
+			value.List = append(value.List, item)
+			block.Fields[currentKey] = value
+			continue
+		}
+
+		if line[0] == ' ' || line[0] == '\t' {
+			// Continuation of a folded scalar
+			if currentKey == "" || block.Fields[currentKey].IsList {
+				return block, body, true, fmt.Errorf("line %d: unexpected indentation", lineNo)
+			}
+			value := block.Fields[currentKey]
+			value.Scalar = strings.TrimSpace(value.Scalar + " " + trimmed)
+			block.Fields[currentKey] = value
+			continue
+		}
+
+		key, rawValue, found := strings.Cut(line, ":")
+		key = strings.TrimSpace(key)
+		if !found || key == "" || strings.ContainsAny(key, " \t") {
+			return block, body, true, fmt.Errorf("line %d: expected \"key: value\"", lineNo)
+		}
+		if _, exists := block.Fields[key]; exists {
+			return block, body, true, fmt.Errorf("line %d: duplicate key %q", lineNo, key)
+		}
+
+		value, err := parseValue(strings.TrimSpace(rawValue))
+		if err != nil {
+			return block, body, true, fmt.Errorf("line %d: %s: %w", lineNo, key, err)
+		}
+		if value.Scalar == "|" || value.Scalar == ">" {
+			value.Scalar = ""
+		}
+		block.Keys = append(block.Keys, key)
+		block.Fields[key] = value
+		currentKey = key
+	}
+
+	return block, body, true, nil
+}
+
+func parseValue(raw string) (Value, error) {
+	if strings.HasPrefix(raw, "[") {
+		if !strings.HasSuffix(raw, "]") {
+			return Value{}, fmt.Errorf("unterminated list")
+		}
+		items := make([]string, 0)
+		for _, part := range SplitList(raw[1 : len(raw)-1]) {
+			item, err := unquote(part)
+			if err != nil {
+				return Value{}, err
+			}
+			items = append(items, item)
+		}
+		return Value{List: items, IsList: true, Raw: raw}, nil
+	}
+
+	scalar, err := unquote(raw)
+	if err != nil {
+		return Value{}, err
+	}
+	return Value{Scalar: scalar}, nil
+}
+
+func unquote(raw string) (string, error) {
+	if raw == "" {
+		return "", nil
+	}
+	quote := raw[0]
+	if quote != '"' && quote != '\'' {
+		return raw, nil
+	}
+	if len(raw) < 2 || raw[len(raw)-1] != quote {
+		return "", fmt.Errorf("unterminated quoted string")
+	}
+	inner := raw[1 : len(raw)-1]
+	if quote == '\'' {
+		return strings.ReplaceAll(inner, "''", "'"), nil
+	}
+	return strings.NewReplacer(`\"`, `"`, `\\`, `\`, `\n`, "\n", `\t`, "\t").Replace(inner), nil
+}
+
+// SplitList splits a comma separated list, ignoring commas inside braces,
+// brackets and quotes so that globs like "*.{ts,tsx}" stay intact.
+func SplitList(raw string) []string {
+	items := make([]string, 0)
+	depth := 0
+	var quote byte
+	last := 0
+	for i := 0; i < len(raw); i++ {
+		c := raw[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '{' || c == '[':
+			depth++
+		case c == '}' || c == ']':
+			depth--
+		case c == ',' && depth == 0:
+			items = appendItem(items, raw[last:i])
+			last = i + 1
+		}
+	}
+	return appendItem(items, raw[last:])
+}
+
+func appendItem(items []string, item string) []string {
+	item = strings.TrimSpace(item)
+	if item == "" {
+		return items
+	}
+	return append(items, item)
+}
+
+// Applies reports whether file is of a kind that carries frontmatter: Cursor
+// .mdc rules, Copilot *.instructions.md files and Claude commands, agents and
+// skills. Memory files like CLAUDE.md may open with a "---" horizontal rule
+// instead.
+func Applies(file types.FileItem) bool {
+	switch file.Type() {
+	case types.CursorRule, types.ClaudeCommand, types.ClaudeAgent, types.ClaudeSkill:
+		return true
+	}
+	return strings.HasSuffix(file.Path, ".mdc") || strings.HasSuffix(file.Path, ".instructions.md")
+}
+
+// ParseMetadata extracts rule metadata from content. It returns nil when the
+// content has no frontmatter block.
+func ParseMetadata(content string) *types.Metadata {
+	block, _, ok, err := Parse(content)
+	if !ok {
+		return nil
+	}
+
+	metadata := &types.Metadata{
+		Fields: make(map[string]string),
+	}
+	if err != nil {
+		metadata.Error = err.Error()
+	}
+	if block == nil {
+		return metadata
+	}
+
+	metadata.Keys = block.Keys
+	metadata.BodyLine = block.Lines + 1
+	for key, value := range block.Fields {
+		metadata.Fields[key] = value.String()
+	}
+
+	metadata.Description = block.Fields["description"].String()
+	metadata.Model = block.Fields["model"].String()
+	// Hints like "[message]" read as inline lists but are meant literally
+	if hint := block.Fields["argument-hint"]; hint.Raw != "" {
+		metadata.ArgumentHint = hint.Raw
+	} else {
+		metadata.ArgumentHint = hint.String()
+	}
+
+	tools, ok := block.Fields["allowed-tools"]
+	if !ok {
+		tools, ok = block.Fields["tools"]
+	}
+	if ok {
+		if tools.IsList {
+			metadata.AllowedTools = tools.List
+		} else {
+			metadata.AllowedTools = SplitList(tools.Scalar)
+		}
+	}
+
+	if globs, ok := block.Fields["globs"]; ok {
+		if globs.IsList {
+			metadata.Globs = globs.List
+		} else {
+			metadata.Globs = SplitList(globs.Scalar)
+		}
+	}
+
+	if alwaysApply, ok := block.Fields["alwaysApply"]; ok {
+		switch strings.ToLower(alwaysApply.Scalar) {
+		case "true":
+			metadata.AlwaysApply = true
+		case "false", "":
+		default:
+			if metadata.Error == "" {
+				metadata.Error = fmt.Sprintf("alwaysApply: expected true or false, got %q", alwaysApply.Scalar)
+			}
+		}
+	}
+
+	return metadata
+}
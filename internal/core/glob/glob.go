@@ -0,0 +1,156 @@
+package glob
+
+import (
+	"errors"
+	"path"
+	"strings"
+)
+
+var ErrBadPattern = errors.New("syntax error in glob pattern")
+
+// Match reports whether name matches the doublestar pattern. Both are
+// slash-separated; "**" as a whole segment matches zero or more directories
+// and "{a,b}" expands to alternatives.
+func Match(pattern, name string) bool {
+	for _, alt := range Expand(pattern) {
+		if matchSegments(splitPath(alt), splitPath(name)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate returns ErrBadPattern when the pattern can never match because of
+// unbalanced brackets or braces.
+func Validate(pattern string) error {
+	if pattern == "" {
+		return ErrBadPattern
+	}
+	depth := 0
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '\\':
+			i++
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth < 0 {
+				return ErrBadPattern
+			}
+		}
+	}
+	if depth != 0 {
+		return ErrBadPattern
+	}
+
+	for _, alt := range Expand(pattern) {
+		for _, segment := range splitPath(alt) {
+			if segment == "**" {
+				continue
+			}
+			if _, err := path.Match(segment, ""); err != nil {
+				return ErrBadPattern
+			}
+		}
+	}
+	return nil
+}
+
+// Expand returns every alternative of the pattern's brace groups.
+func Expand(pattern string) []string {
+	start := -1
+	depth := 0
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '\\':
+			i++
+		case '{':
+			if depth == 0 {
+				start = i
+			}
+			depth++
+		case '}':
+			if depth == 0 {
+				continue
+			}
+			depth--
+			if depth == 0 {
+				prefix, suffix := pattern[:start], pattern[i+1:]
+				result := make([]string, 0)
+				for _, option := range splitOptions(pattern[start+1 : i]) {
+					result = append(result, Expand(prefix+option+suffix)...)
+				}
+				return result
+			}
+		}
+	}
+	return []string{pattern}
+}
+
+// HasMeta reports whether the pattern contains any glob syntax.
+func HasMeta(pattern string) bool {
+	return strings.ContainsAny(pattern, "*?[{\\")
+}
+
+func splitOptions(body string) []string {
+	options := make([]string, 0)
+	depth := 0
+	last := 0
+	for i := 0; i < len(body); i++ {
+		switch body[i] {
+		case '\\':
+			i++
+		case '{':
+			depth++
+		case '}':
+			depth--
+		case ',':
+			if depth == 0 {
+				options = append(options, body[last:i])
+				last = i + 1
+			}
+		}
+	}
+	return append(options, body[last:])
+}
+
+func splitPath(p string) []string {
+	p = strings.Trim(p, "/")
+	if p == "" {
+		return []string{}
+	}
+	return strings.Split(p, "/")
+}
+
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			// Collapse repeated ** segments and try every split point
+			rest := pattern[1:]
+			for len(rest) > 0 && rest[0] == "**" {
+				rest = rest[1:]
+			}
+			if len(rest) == 0 {
+				return true
+			}
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(rest, name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+
+		if len(name) == 0 {
+			return false
+		}
+		segment := strings.ReplaceAll(pattern[0], "**", "*")
+		if ok, err := path.Match(segment, name[0]); err != nil || !ok {
+			return false
+		}
+		pattern = pattern[1:]
+		name = name[1:]
+	}
+	return len(name) == 0
+}
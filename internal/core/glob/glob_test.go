@@ -0,0 +1,77 @@
+package glob
+
+import "testing"
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{"*.mdc", "rule.mdc", true},
+		{"*.mdc", "rules/rule.mdc", false},
+		{"**/*.mdc", "rule.mdc", true},
+		{"**/*.mdc", "a/b/c/rule.mdc", true},
+		{"**/.cursor/rules/*.mdc", ".cursor/rules/a.mdc", true},
+		{"**/.cursor/rules/*.mdc", "pkg/.cursor/rules/a.mdc", true},
+		{"**/.cursor/rules/*.mdc", "pkg/.cursor/rules/sub/a.mdc", false},
+		{"src/**", "src", true},
+		{"src/**", "src/a/b.ts", true},
+		{"src/**", "srcs/a.ts", false},
+		{"src/**/test/*.ts", "src/test/a.ts", true},
+		{"src/**/test/*.ts", "src/a/b/test/a.ts", true},
+		{"src/**/**/*.ts", "src/a.ts", true},
+		{"**", "", true},
+		{"**", "a/b", true},
+		// ** inside a segment is a plain *
+		{"src/**.ts", "src/a.ts", true},
+		{"src/**.ts", "src/a/b.ts", false},
+		{"*.{ts,tsx}", "app.tsx", true},
+		{"*.{ts,tsx}", "app.js", false},
+		{"{src,lib}/**/*.{ts,js}", "lib/x/y.js", true},
+		{"a?c", "abc", true},
+		{"a?c", "a/c", false},
+		{"[a-c].md", "b.md", true},
+		{"/src/*.ts", "src/a.ts", true},
+	}
+
+	for _, tt := range tests {
+		if got := Match(tt.pattern, tt.name); got != tt.want {
+			t.Errorf("Match(%q, %q) = %v, want %v", tt.pattern, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		pattern string
+		ok      bool
+	}{
+		{"**/*.ts", true},
+		{"*.{ts,tsx}", true},
+		{`\{literal\}`, true},
+		{"", false},
+		{"*.{ts,tsx", false},
+		{"*.ts}", false},
+		{"[a-", false},
+	}
+
+	for _, tt := range tests {
+		if err := Validate(tt.pattern); (err == nil) != tt.ok {
+			t.Errorf("Validate(%q) = %v, want ok %v", tt.pattern, err, tt.ok)
+		}
+	}
+}
+
+func TestExpand(t *testing.T) {
+	got := Expand("{a,b{c,d}}/*.{x,y}")
+	want := []string{"a/*.x", "a/*.y", "bc/*.x", "bc/*.y", "bd/*.x", "bd/*.y"}
+	if len(got) != len(want) {
+		t.Fatalf("Expand = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Expand = %v, want %v", got, want)
+		}
+	}
+}
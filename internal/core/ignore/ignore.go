@@ -0,0 +1,314 @@
+package ignore
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"rules-explorer/internal/core/glob"
+)
+
+// DefaultSkipDirs are never worth descending into when looking for rule files.
+var DefaultSkipDirs = []string{
+	".git",
+	".hg",
+	".svn",
+	"node_modules",
+	"bower_components",
+	"vendor",
+	".venv",
+	"venv",
+	"__pycache__",
+	".tox",
+	"dist",
+	"build",
+	"out",
+	"target",
+	".next",
+	".nuxt",
+	".turbo",
+	".cache",
+	".gradle",
+	"coverage",
+}
+
+func IsDefaultSkipDir(name string) bool {
+	for _, dir := range DefaultSkipDirs {
+		if name == dir {
+			return true
+		}
+	}
+	return false
+}
+
+type rule struct {
+	pattern  string
+	negate   bool
+	dirOnly  bool
+	anchored bool
+}
+
+type ruleSet struct {
+	// base is the slash-separated directory the rules are relative to, "" for
+	// the repository root
+	base  string
+	rules []rule
+}
+
+// Matcher evaluates gitignore rules for paths below root. Nested .gitignore
+// files are read lazily the first time a path below them is checked.
+type Matcher struct {
+	repoRoot string
+	prefix   string
+	global   []ruleSet
+
+	mu    sync.Mutex
+	cache map[string]*ruleSet
+}
+
+// New builds a matcher for root. If root is inside a git work tree, rules from
+// the repository root down to root, .git/info/exclude and the global excludes
+// file all apply.
+func New(root string) *Matcher {
+	repoRoot := findRepoRoot(root)
+	prefix := ""
+	if rel, err := filepath.Rel(repoRoot, root); err == nil && rel != "." {
+		prefix = filepath.ToSlash(rel)
+	}
+
+	m := &Matcher{
+		repoRoot: repoRoot,
+		prefix:   prefix,
+		cache:    make(map[string]*ruleSet),
+	}
+
+	if file := globalExcludesFile(); file != "" {
+		m.global = append(m.global, readRules(file, ""))
+	}
+	m.global = append(m.global, readRules(filepath.Join(repoRoot, ".git", "info", "exclude"), ""))
+
+	return m
+}
+
+// Match reports whether relPath (relative to root) is ignored by the rules in
+// effect for its directory. Ancestors are not checked; callers walking the
+// tree prune ignored directories themselves.
+func (m *Matcher) Match(relPath string, isDir bool) bool {
+	p := m.repoPath(relPath)
+	if p == "" {
+		return false
+	}
+
+	ignored := false
+	for _, set := range m.ruleSetsFor(path.Dir(p)) {
+		if matched, negate := set.match(p, isDir); matched {
+			ignored = !negate
+		}
+	}
+	return ignored
+}
+
+// IsIgnored reports whether relPath or any of its parent directories is
+// ignored or in the default skip list.
+func (m *Matcher) IsIgnored(relPath string, isDir bool) bool {
+	relPath = filepath.ToSlash(relPath)
+	parts := strings.Split(relPath, "/")
+	for i := 1; i < len(parts); i++ {
+		if IsDefaultSkipDir(parts[i-1]) || m.Match(strings.Join(parts[:i], "/"), true) {
+			return true
+		}
+	}
+	return m.Match(relPath, isDir)
+}
+
+func (m *Matcher) repoPath(relPath string) string {
+	relPath = strings.TrimPrefix(filepath.ToSlash(relPath), "./")
+	if relPath == "." {
+		relPath = ""
+	}
+	if m.prefix == "" {
+		return relPath
+	}
+	if relPath == "" {
+		return m.prefix
+	}
+	return m.prefix + "/" + relPath
+}
+
+// ruleSetsFor returns rule sets in increasing precedence for paths in dir.
+func (m *Matcher) ruleSetsFor(dir string) []*ruleSet {
+	sets := make([]*ruleSet, 0)
+	for i := range m.global {
+		sets = append(sets, &m.global[i])
+	}
+
+	if dir == "." {
+		dir = ""
+	}
+	current := ""
+	sets = append(sets, m.load(current))
+	if dir != "" {
+		for _, part := range strings.Split(dir, "/") {
+			current = path.Join(current, part)
+			sets = append(sets, m.load(current))
+		}
+	}
+	return sets
+}
+
+func (m *Matcher) load(dir string) *ruleSet {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if set, ok := m.cache[dir]; ok {
+		return set
+	}
+	set := readRules(filepath.Join(m.repoRoot, filepath.FromSlash(dir), ".gitignore"), dir)
+	m.cache[dir] = &set
+	return &set
+}
+
+func (s *ruleSet) match(p string, isDir bool) (matched bool, negate bool) {
+	rel := p
+	if s.base != "" {
+		if !strings.HasPrefix(p, s.base+"/") {
+			return false, false
+		}
+		rel = p[len(s.base)+1:]
+	}
+
+	// Later rules take precedence, so scan backwards
+	for i := len(s.rules) - 1; i >= 0; i-- {
+		r := s.rules[i]
+		if r.dirOnly && !isDir {
+			continue
+		}
+		target := rel
+		if !r.anchored {
+			target = path.Base(rel)
+		}
+		if glob.Match(r.pattern, target) {
+			return true, r.negate
+		}
+	}
+	return false, false
+}
+
+func readRules(file string, base string) ruleSet {
+	set := ruleSet{base: base}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return set
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if r, ok := parseRule(scanner.Text()); ok {
+			set.rules = append(set.rules, r)
+		}
+	}
+	return set
+}
+
+func parseRule(line string) (rule, bool) {
+	line = strings.TrimSuffix(line, "\r")
+	if strings.HasSuffix(line, "\\ ") {
+		line = strings.TrimRight(line[:len(line)-2], " ") + "\\ "
+	} else {
+		line = strings.TrimRight(line, " \t")
+	}
+	if line == "" || strings.HasPrefix(line, "#") {
+		return rule{}, false
+	}
+
+	r := rule{}
+	if strings.HasPrefix(line, "!") {
+		r.negate = true
+		line = line[1:]
+	} else if strings.HasPrefix(line, "\\!") || strings.HasPrefix(line, "\\#") {
+		line = line[1:]
+	}
+
+	if strings.HasSuffix(line, "/") {
+		r.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+	if strings.Contains(line, "/") {
+		r.anchored = true
+		line = strings.TrimPrefix(line, "/")
+	}
+	if line == "" {
+		return rule{}, false
+	}
+
+	r.pattern = line
+	return r, true
+}
+
+func findRepoRoot(root string) string {
+	dir := root
+	for {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return root
+		}
+		dir = parent
+	}
+}
+
+func globalExcludesFile() string {
+	home, _ := os.UserHomeDir()
+
+	if home != "" {
+		if file := readExcludesFileSetting(filepath.Join(home, ".gitconfig")); file != "" {
+			if strings.HasPrefix(file, "~/") {
+				file = filepath.Join(home, file[2:])
+			}
+			return file
+		}
+	}
+
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		return filepath.Join(xdg, "git", "ignore")
+	}
+	if home != "" {
+		return filepath.Join(home, ".config", "git", "ignore")
+	}
+	return ""
+}
+
+// readExcludesFileSetting extracts core.excludesFile from a git config file.
+// Only the plain "[core]" section form is understood.
+func readExcludesFileSetting(gitconfig string) string {
+	f, err := os.Open(gitconfig)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	inCore := false
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") {
+			inCore = strings.EqualFold(strings.Trim(line, "[] "), "core")
+			continue
+		}
+		if !inCore {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if ok && strings.EqualFold(strings.TrimSpace(key), "excludesfile") {
+			return strings.Trim(strings.TrimSpace(value), `"`)
+		}
+	}
+	return ""
+}
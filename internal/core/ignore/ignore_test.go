@@ -0,0 +1,141 @@
+package ignore
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeTree creates files (slash-separated paths to contents) below a new
+// repository root
+func writeTree(t *testing.T, files map[string]string) string {
+	t.Helper()
+	// Keep the user's global excludes out of the test
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestMatch(t *testing.T) {
+	root := writeTree(t, map[string]string{
+		".gitignore": `# comment
+*.log
+!keep.log
+/build.txt
+docs/*.tmp
+cache/
+**/generated/*.md
+\#hash
+trailing   
+`,
+		"pkg/.gitignore":       "!*.log\nlocal.md\n",
+		".git/info/exclude":    "secret.md\n",
+		"pkg/sub/.gitignore":   "/anchored.md\n",
+		"other/.gitignore":     "*.md\n",
+		"other/sub/.gitignore": "!readme.md\n",
+	})
+	m := New(root)
+
+	tests := []struct {
+		path  string
+		isDir bool
+		want  bool
+	}{
+		{"app.log", false, true},
+		{"a/b/app.log", false, true},
+		{"keep.log", false, false},
+		{"a/keep.log", false, false},
+
+		// A leading slash anchors to the .gitignore's directory
+		{"build.txt", false, true},
+		{"a/build.txt", false, false},
+		// So does a slash in the middle
+		{"docs/x.tmp", false, true},
+		{"a/docs/x.tmp", false, false},
+		{"docs/sub/x.tmp", false, false},
+
+		// A trailing slash only matches directories, at any depth
+		{"cache", true, true},
+		{"a/cache", true, true},
+		{"cache", false, false},
+
+		{"generated/x.md", false, true},
+		{"a/b/generated/x.md", false, true},
+
+		{"#hash", false, true},
+		{"trailing", false, true},
+
+		// Nested files override their parents
+		{"pkg/app.log", false, false},
+		{"pkg/local.md", false, true},
+		{"pkg/a/local.md", false, true},
+		{"local.md", false, false},
+		{"pkg/sub/anchored.md", false, true},
+		{"pkg/sub/a/anchored.md", false, false},
+		{"other/x.md", false, true},
+		{"other/sub/readme.md", false, false},
+		{"other/sub/x.md", false, true},
+
+		{"secret.md", false, true},
+		{"CLAUDE.md", false, false},
+		{".", true, false},
+	}
+
+	for _, tt := range tests {
+		if got := m.Match(tt.path, tt.isDir); got != tt.want {
+			t.Errorf("Match(%q, %v) = %v, want %v", tt.path, tt.isDir, got, tt.want)
+		}
+	}
+}
+
+func TestIsIgnoredChecksParents(t *testing.T) {
+	root := writeTree(t, map[string]string{
+		".gitignore": "cache/\n!cache/keep.md\n",
+	})
+	m := New(root)
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		// A file can't be re-included when its directory is excluded
+		{"cache/keep.md", true},
+		{"node_modules/pkg/CLAUDE.md", true},
+		{"src/CLAUDE.md", false},
+	}
+
+	for _, tt := range tests {
+		if got := m.IsIgnored(tt.path, false); got != tt.want {
+			t.Errorf("IsIgnored(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestSubdirectoryRoot(t *testing.T) {
+	root := writeTree(t, map[string]string{
+		".gitignore": "/pkg/ignored.md\n",
+	})
+	m := New(filepath.Join(root, "pkg"))
+
+	if !m.Match("ignored.md", false) {
+		t.Error("rules from the repository root should apply below it")
+	}
+	if m.Match("other.md", false) {
+		t.Error("other.md should not be ignored")
+	}
+}
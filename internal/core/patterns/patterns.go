@@ -0,0 +1,146 @@
+package patterns
+
+import (
+	"path/filepath"
+
+	"rules-explorer/internal/core/glob"
+)
+
+// Pattern describes one class of files to discover. Include and Exclude are
+// doublestar globs matched against slash-separated paths relative to the root.
+type Pattern struct {
+	Name    string   `json:"name"`
+	Type    string   `json:"type"`
+	Include []string `json:"include"`
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+func (p Pattern) Matches(relPath string) bool {
+	relPath = filepath.ToSlash(relPath)
+
+	for _, exclude := range p.Exclude {
+		if glob.Match(exclude, relPath) {
+			return false
+		}
+	}
+
+	for _, include := range p.Include {
+		if glob.Match(include, relPath) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type Registry struct {
+	patterns []Pattern
+	exclude  []string
+}
+
+func NewRegistry(patterns []Pattern, exclude []string) *Registry {
+	return &Registry{
+		patterns: patterns,
+		exclude:  exclude,
+	}
+}
+
+func Default() *Registry {
+	return NewRegistry(DefaultPatterns(), nil)
+}
+
+func DefaultPatterns() []Pattern {
+	return []Pattern{
+		{
+			Name:    "Cursor rules",
+			Type:    "cursor",
+			Include: []string{"**/.cursor/rules/*.mdc"},
+		},
+		{
+			Name:    "Claude memory",
+			Type:    "claude",
+			Include: []string{"**/CLAUDE.md", "**/CLAUDE.local.md"},
+		},
+		{
+			Name:    "Claude settings",
+			Type:    "config",
+			Include: []string{".claude/*"},
+		},
+		{
+			Name:    "Claude commands",
+			Type:    "command",
+			Include: []string{".claude/commands/**/*.md"},
+		},
+		{
+			Name:    "Claude subagents",
+			Type:    "subagent",
+			Include: []string{".claude/agents/*.md"},
+		},
+		{
+			Name:    "Claude skills",
+			Type:    "skill",
+			Include: []string{".claude/skills/*/SKILL.md"},
+		},
+		{
+			Name:    "MCP servers",
+			Type:    "config",
+			Include: []string{".mcp.json"},
+		},
+		{
+			Name:    "Agents instructions",
+			Type:    "agents",
+			Include: []string{"**/AGENTS.md"},
+		},
+		{
+			Name:    "Gemini memory",
+			Type:    "gemini",
+			Include: []string{"**/GEMINI.md"},
+		},
+		{
+			Name: "Copilot instructions",
+			Type: "copilot",
+			Include: []string{
+				".github/copilot-instructions.md",
+				".github/instructions/**/*.instructions.md",
+			},
+		},
+		{
+			Name:    "Windsurf rules",
+			Type:    "windsurf",
+			Include: []string{"**/.windsurfrules", "**/.windsurf/rules/*.md"},
+		},
+		{
+			Name:    "Cline rules",
+			Type:    "cline",
+			Include: []string{"**/.clinerules", "**/.clinerules/**"},
+		},
+	}
+}
+
+func (r *Registry) Patterns() []Pattern {
+	return r.patterns
+}
+
+func (r *Registry) Excludes() []string {
+	return r.exclude
+}
+
+// Match returns the first pattern that accepts relPath. Global excludes take
+// precedence over every pattern.
+func (r *Registry) Match(relPath string) (Pattern, bool) {
+	relPath = filepath.ToSlash(relPath)
+
+	for _, exclude := range r.exclude {
+		if glob.Match(exclude, relPath) {
+			return Pattern{}, false
+		}
+	}
+
+	for _, pattern := range r.patterns {
+		if pattern.Matches(relPath) {
+			return pattern, true
+		}
+	}
+
+	return Pattern{}, false
+}
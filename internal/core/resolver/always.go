@@ -0,0 +1,93 @@
+package resolver
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"rules-explorer/internal/core/claudemd"
+	"rules-explorer/internal/core/types"
+)
+
+// AlwaysApplied returns the files loaded into every request made from the
+// project root: root-level memory and instruction files, alwaysApply Cursor
+// rules at the root and Cline rules. Files imported by the Claude memory files
+// are read from disk and included once each, without a pattern label. root is
+// the directory file paths are relative to.
+func AlwaysApplied(root string, files []types.FileItem) []types.FileItem {
+	applied := make([]types.FileItem, 0)
+	seen := make(map[string]bool)
+
+	for _, file := range files {
+		if !alwaysApplied(file) {
+			continue
+		}
+		applied = append(applied, file)
+		seen[absPath(root, file.Path)] = true
+	}
+
+	// Imports may point at other discovered files, so they're added after
+	// every discovered file is marked as seen
+	for _, file := range applied {
+		if file.Type() != types.ClaudeConfig {
+			continue
+		}
+		for _, imp := range claudemd.ResolveImports(filepath.Join(root, file.Path), file.Content) {
+			applied = appendImports(applied, root, imp, seen)
+		}
+	}
+
+	return applied
+}
+
+func alwaysApplied(file types.FileItem) bool {
+	p := Clean(file.Path)
+	switch file.Type() {
+	case types.CursorRule:
+		scope, _ := Scope(file)
+		return scope == "" && file.Metadata.Mode() == types.RuleAlways
+	case types.ClaudeConfig:
+		return p == "CLAUDE.md" || p == ".claude/CLAUDE.md" || p == "CLAUDE.local.md"
+	case types.AgentsConfig:
+		return p == "AGENTS.md"
+	case types.GeminiConfig:
+		return p == "GEMINI.md"
+	case types.CopilotInstructions:
+		return p == ".github/copilot-instructions.md"
+	case types.WindsurfRule:
+		return p == ".windsurfrules"
+	case types.ClineRule:
+		return p == ".clinerules" || strings.HasPrefix(p, ".clinerules/")
+	}
+	return false
+}
+
+func appendImports(applied []types.FileItem, root string, imp *claudemd.Import, seen map[string]bool) []types.FileItem {
+	if imp.Missing || imp.Cycle || seen[imp.Path] {
+		return applied
+	}
+	seen[imp.Path] = true
+
+	data, err := os.ReadFile(imp.Path)
+	if err != nil {
+		return applied
+	}
+	display := imp.Path
+	if rel, err := filepath.Rel(absPath(root, ""), imp.Path); err == nil && !strings.HasPrefix(rel, "..") {
+		display = rel
+	}
+	applied = append(applied, types.FileItem{Path: display, Content: string(data)})
+
+	for _, child := range imp.Children {
+		applied = appendImports(applied, root, child, seen)
+	}
+	return applied
+}
+
+func absPath(root, p string) string {
+	abs, err := filepath.Abs(filepath.Join(root, p))
+	if err != nil {
+		return filepath.Join(root, p)
+	}
+	return abs
+}
@@ -0,0 +1,133 @@
+package resolver
+
+import (
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"rules-explorer/internal/core/glob"
+	"rules-explorer/internal/core/types"
+)
+
+type Reason int
+
+const (
+	ReasonAlways Reason = iota
+	ReasonGlob
+)
+
+func (r Reason) String() string {
+	switch r {
+	case ReasonAlways:
+		return "always"
+	case ReasonGlob:
+		return "glob"
+	default:
+		return "unknown"
+	}
+}
+
+// Match is a Cursor rule that attaches to the resolved path.
+type Match struct {
+	File   types.FileItem
+	Reason Reason
+	// Glob is the pattern that matched when Reason is ReasonGlob
+	Glob string
+	// Scope is the directory holding the rule's .cursor folder, "" for the root
+	Scope string
+}
+
+// Scope returns the directory a Cursor rule applies to: the parent of the
+// .cursor/rules directory that holds it. ok is false for non-Cursor files.
+func Scope(file types.FileItem) (scope string, ok bool) {
+	if file.Type() != types.CursorRule {
+		return "", false
+	}
+
+	p := filepath.ToSlash(file.Path)
+	if p == ".cursor/rules" || strings.HasPrefix(p, ".cursor/rules/") {
+		return "", true
+	}
+	if i := strings.LastIndex(p, "/.cursor/rules/"); i >= 0 {
+		return p[:i], true
+	}
+	return path.Dir(p), true
+}
+
+// Resolve returns the Cursor rules that attach to target, a path relative to
+// the project root. Rules live in .cursor/rules directories at the root or in
+// any ancestor of target; their globs are relative to that ancestor.
+func Resolve(files []types.FileItem, target string) []Match {
+	target = Clean(target)
+	matches := make([]Match, 0)
+
+	for _, file := range files {
+		scope, ok := Scope(file)
+		if !ok || !InScope(scope, target) {
+			continue
+		}
+
+		if file.Metadata != nil && file.Metadata.AlwaysApply {
+			matches = append(matches, Match{File: file, Reason: ReasonAlways, Scope: scope})
+			continue
+		}
+
+		if g, ok := MatchGlobs(file.Metadata, scope, target); ok {
+			matches = append(matches, Match{File: file, Reason: ReasonGlob, Glob: g, Scope: scope})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Reason != matches[j].Reason {
+			return matches[i].Reason < matches[j].Reason
+		}
+		if len(matches[i].Scope) != len(matches[j].Scope) {
+			return len(matches[i].Scope) < len(matches[j].Scope)
+		}
+		return matches[i].File.Path < matches[j].File.Path
+	})
+
+	return matches
+}
+
+// MatchGlobs returns the first glob of metadata matching target. Globs without
+// a slash match the file name at any depth below scope.
+func MatchGlobs(metadata *types.Metadata, scope string, target string) (string, bool) {
+	if metadata == nil {
+		return "", false
+	}
+
+	rel := target
+	if scope != "" {
+		rel = strings.TrimPrefix(target, scope+"/")
+	}
+
+	for _, g := range metadata.Globs {
+		pattern := strings.TrimPrefix(g, "./")
+		pattern = strings.TrimPrefix(pattern, "/")
+		if !strings.Contains(pattern, "/") {
+			pattern = "**/" + pattern
+		}
+		if glob.Match(pattern, rel) {
+			return g, true
+		}
+	}
+	return "", false
+}
+
+// InScope reports whether target is inside the scope directory.
+func InScope(scope, target string) bool {
+	return scope == "" || target == scope || strings.HasPrefix(target, scope+"/")
+}
+
+// Clean normalises a user supplied path to the slash-separated relative form
+// used for file items.
+func Clean(p string) string {
+	p = path.Clean(filepath.ToSlash(p))
+	p = strings.TrimPrefix(p, "./")
+	if p == "." {
+		return ""
+	}
+	return p
+}
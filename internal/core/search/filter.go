@@ -1,42 +1,147 @@
 package search
 
 import (
-	"strings"
+	"context"
+	"sort"
 	"rules-explorer/internal/core/types"
 )
 
+// Score tiers keep every path hit above metadata hits, and metadata hits
+// above content-only hits
+const (
+	tierPath     = 2_000_000
+	tierMetadata = 1_000_000
+	tierContent  = 0
+)
+
 type Filter struct {
-	query string
+	query     string
+	regexMode bool
+	parsed    *Query
+	err       error
+	index     *Index
+	fields    map[string]bool
+}
+
+type Result struct {
+	File  types.FileItem
+	Score int
 }
 
 func NewFilter() *Filter {
 	return &Filter{}
 }
 
-func (f *Filter) SetQuery(query string) {
-	f.query = strings.ToLower(query)
+// SetQuery parses query. On a syntax error the previous query stays in
+// effect and the error is returned (and kept for Err).
+func (f *Filter) SetQuery(query string) error {
+	// The pattern is compiled once here and reused for every file
+	var parsed *Query
+	var err error
+	if f.regexMode {
+		parsed, err = ParseRegexQuery(query)
+	} else {
+		parsed, err = ParseQuery(query, f.fields)
+	}
+	f.err = err
+	if err != nil {
+		return err
+	}
+	
+	f.query = query
+	f.parsed = parsed
+	return nil
+}
+
+// SetRegexMode makes the whole query a single regular expression. The current
+// query is re-parsed in the new mode.
+func (f *Filter) SetRegexMode(enabled bool) error {
+	f.regexMode = enabled
+	return f.SetQuery(f.query)
+}
+
+// SetFields sets the frontmatter keys a query may use as fields, usually
+// FieldNames of the files searched. The current query is re-parsed.
+func (f *Filter) SetFields(fields map[string]bool) error {
+	f.fields = fields
+	return f.SetQuery(f.query)
+}
+
+// SetIndex makes the filter use idx to narrow content matching. Files the
+// index doesn't know about are matched in full.
+func (f *Filter) SetIndex(idx *Index) {
+	f.index = idx
+}
+
+func (f *Filter) RegexMode() bool {
+	return f.regexMode
+}
+
+func (f *Filter) Err() error {
+	return f.err
+}
+
+// Highlights returns the ranges of content matched by the current query
+func (f *Filter) Highlights(content string) []types.Span {
+	return f.parsed.Highlights(content)
 }
 
 func (f *Filter) Match(file types.FileItem) bool {
-	if f.query == "" {
-		return true
+	_, ok := f.Score(file)
+	return ok
+}
+
+// Score ranks a file against the query; ok is false when it doesn't match
+func (f *Filter) Score(file types.FileItem) (int, bool) {
+	return f.parsed.ScoreIndexed(file, f.index)
+}
+
+// Rank returns the matching files with their scores, best first. Files with
+// equal scores keep their original order.
+func (f *Filter) Rank(files []types.FileItem) []Result {
+	results, _ := f.RankContext(context.Background(), files)
+	return results
+}
+
+// RankContext is Rank, stopping early with ctx's error when it is cancelled
+func (f *Filter) RankContext(ctx context.Context, files []types.FileItem) ([]Result, error) {
+	results := make([]Result, 0)
+	for i, file := range files {
+		if i%64 == 0 && ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if score, ok := f.Score(file); ok {
+			results = append(results, Result{File: file, Score: score})
+		}
 	}
 	
-	return strings.Contains(strings.ToLower(file.Path), f.query) ||
-		strings.Contains(strings.ToLower(file.Content), f.query)
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+	
+	return results, nil
 }
 
 func (f *Filter) FilterFiles(files []types.FileItem) []types.FileItem {
-	if f.query == "" {
-		return files
+	filtered, _ := f.FilterFilesContext(context.Background(), files)
+	return filtered
+}
+
+// FilterFilesContext is FilterFiles, stopping early with ctx's error when it
+// is cancelled
+func (f *Filter) FilterFilesContext(ctx context.Context, files []types.FileItem) ([]types.FileItem, error) {
+	if f.parsed.Empty() {
+		return files, nil
 	}
 	
-	filtered := make([]types.FileItem, 0)
-	for _, file := range files {
-		if f.Match(file) {
-			filtered = append(filtered, file)
-		}
+	results, err := f.RankContext(ctx, files)
+	if err != nil {
+		return nil, err
+	}
+	filtered := make([]types.FileItem, 0, len(results))
+	for _, result := range results {
+		filtered = append(filtered, result.File)
 	}
 	
-	return filtered
-}
\ No newline at end of file
+	return filtered, nil
+}
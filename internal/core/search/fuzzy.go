@@ -0,0 +1,141 @@
+package search
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// Scoring constants for FuzzyMatch, modelled on fzf's v1 algorithm: every
+// matched character earns scoreMatch, characters at word boundaries or right
+// after a previous match earn bonuses, and gaps cost a little.
+const (
+	scoreMatch        = 16
+	scoreGapStart     = -3
+	scoreGapExtension = -1
+
+	bonusBoundary    = 8
+	bonusSeparator   = 10
+	bonusCamel       = 7
+	bonusConsecutive = 6
+	bonusFirstChar   = 4
+	bonusBasename    = 24
+)
+
+// FuzzyMatch reports whether every rune of pattern occurs in text in order,
+// ignoring case. The score favours matches in the base name of a path, at
+// word boundaries and in consecutive runs; positions holds the rune indexes
+// of the matched characters.
+func FuzzyMatch(pattern, text string) (score int, positions []int, ok bool) {
+	if pattern == "" {
+		return 0, nil, true
+	}
+
+	p := []rune(toLower(pattern))
+	t := []rune(text)
+	lower := []rune(toLower(text))
+
+	// Forward pass: find the earliest window end
+	pi := 0
+	end := -1
+	for i := 0; i < len(lower) && pi < len(p); i++ {
+		if lower[i] == p[pi] {
+			pi++
+			if pi == len(p) {
+				end = i
+			}
+		}
+	}
+	if end < 0 {
+		return 0, nil, false
+	}
+
+	// Backward pass: tighten the window start
+	pi = len(p) - 1
+	start := end
+	for i := end; i >= 0 && pi >= 0; i-- {
+		if lower[i] == p[pi] {
+			pi--
+			start = i
+		}
+	}
+
+	basename := lastSeparator(t) + 1
+	positions = make([]int, 0, len(p))
+	pi = 0
+	consecutive := 0
+	inGap := false
+	for i := start; i <= end && pi < len(p); i++ {
+		if lower[i] != p[pi] {
+			if inGap {
+				score += scoreGapExtension
+			} else {
+				score += scoreGapStart
+			}
+			inGap = true
+			consecutive = 0
+			continue
+		}
+
+		bonus := boundaryBonus(t, i)
+		if pi == 0 {
+			bonus += bonusFirstChar
+		}
+		if consecutive > 0 {
+			if bonus < bonusConsecutive {
+				bonus = bonusConsecutive
+			}
+			bonus += consecutive
+		}
+
+		score += scoreMatch + bonus
+		positions = append(positions, i)
+		consecutive++
+		inGap = false
+		pi++
+	}
+
+	if start >= basename {
+		score += bonusBasename
+		if start == basename {
+			score += bonusBasename / 2
+		}
+	}
+
+	return score, positions, true
+}
+
+func boundaryBonus(text []rune, i int) int {
+	if i == 0 {
+		return bonusSeparator
+	}
+	prev, cur := text[i-1], text[i]
+	switch {
+	case prev == '/' || prev == '\\':
+		return bonusSeparator
+	case prev == '_' || prev == '-' || prev == '.' || prev == ' ':
+		return bonusBoundary
+	case unicode.IsLower(prev) && unicode.IsUpper(cur):
+		return bonusCamel
+	case !unicode.IsLetter(prev) && !unicode.IsDigit(prev) && (unicode.IsLetter(cur) || unicode.IsDigit(cur)):
+		return bonusBoundary
+	}
+	return 0
+}
+
+func lastSeparator(text []rune) int {
+	for i := len(text) - 1; i >= 0; i-- {
+		if text[i] == '/' || text[i] == '\\' {
+			return i
+		}
+	}
+	return -1
+}
+
+func toLower(s string) string {
+	// Lowercase rune by rune so that rune indexes line up with the original
+	b := make([]byte, 0, len(s))
+	for _, r := range s {
+		b = utf8.AppendRune(b, unicode.ToLower(r))
+	}
+	return string(b)
+}
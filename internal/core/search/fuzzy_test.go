@@ -0,0 +1,65 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		pattern   string
+		text      string
+		ok        bool
+		positions []int
+	}{
+		{"", "anything", true, nil},
+		{"abc", "abc", true, []int{0, 1, 2}},
+		{"ABC", "abc", true, []int{0, 1, 2}},
+		{"abc", "a_b_c", true, []int{0, 2, 4}},
+		{"acb", "abc", false, nil},
+		{"abcd", "abc", false, nil},
+		{"x", "", false, nil},
+		// the window is tightened to the last start before the first full match
+		{"ab", "a/xab", true, []int{3, 4}},
+		{"rule", "docs/RULES.md", true, []int{5, 6, 7, 8}},
+		// positions are rune indexes, not byte offsets
+		{"ré", "café/régles", true, []int{5, 6}},
+	}
+
+	for _, tt := range tests {
+		_, positions, ok := FuzzyMatch(tt.pattern, tt.text)
+		if ok != tt.ok || !reflect.DeepEqual(positions, tt.positions) {
+			t.Errorf("FuzzyMatch(%q, %q) = %v %v, want %v %v", tt.pattern, tt.text, positions, ok, tt.positions, tt.ok)
+		}
+	}
+}
+
+func TestFuzzyMatchRanking(t *testing.T) {
+	// each pair is (pattern, better, worse)
+	tests := []struct {
+		name    string
+		pattern string
+		better  string
+		worse   string
+	}{
+		{"consecutive run", "test", "test.md", "t_e_s_t.md"},
+		{"word boundary", "ru", "my-rules.md", "bruise.md"},
+		{"camel case boundary", "ts", "myTsConfig.md", "mytsconfig.md"},
+		{"basename over directory", "api", "src/rules/api.mdc", "api/rules/other.mdc"},
+		{"start of basename", "rules", "x/rules.md", "x/my_rules.md"},
+		{"shorter gap", "ab", "axb.md", "axxxxb.md"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			better, _, ok1 := FuzzyMatch(tt.pattern, tt.better)
+			worse, _, ok2 := FuzzyMatch(tt.pattern, tt.worse)
+			if !ok1 || !ok2 {
+				t.Fatalf("expected both %q and %q to match %q", tt.better, tt.worse, tt.pattern)
+			}
+			if better <= worse {
+				t.Errorf("%q scored %d, not above %q at %d", tt.better, better, tt.worse, worse)
+			}
+		})
+	}
+}
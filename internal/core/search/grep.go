@@ -0,0 +1,94 @@
+package search
+
+import (
+	"sort"
+	"strings"
+
+	"rules-explorer/internal/core/types"
+)
+
+// LineMatch is a content line matched by the query
+type LineMatch struct {
+	File types.FileItem
+	// Line and Column are 1-based; Column counts bytes
+	Line   int
+	Column int
+	Text   string
+	// Offset is the byte offset of the first match in the file content
+	Offset int
+	// Spans are the matched ranges relative to Text
+	Spans  []types.Span
+	Before []string
+	After  []string
+}
+
+// GrepLines returns every line with a query match, with up to context lines
+// around it. Files are visited in ranked order.
+func (f *Filter) GrepLines(files []types.FileItem, context int) []LineMatch {
+	matches := make([]LineMatch, 0)
+	if f.parsed.Empty() {
+		return matches
+	}
+
+	for _, result := range f.Rank(files) {
+		matches = append(matches, grepFile(result.File, f.Highlights(result.File.Content), context)...)
+	}
+	return matches
+}
+
+func grepFile(file types.FileItem, spans []types.Span, context int) []LineMatch {
+	if len(spans) == 0 {
+		return nil
+	}
+
+	lines := strings.Split(file.Content, "\n")
+	starts := make([]int, len(lines))
+	offset := 0
+	for i, line := range lines {
+		starts[i] = offset
+		offset += len(line) + 1
+	}
+
+	matches := make([]LineMatch, 0)
+	for _, span := range spans {
+		index := sort.Search(len(starts), func(i int) bool { return starts[i] > span.Start }) - 1
+		lineStart := starts[index]
+		lineEnd := lineStart + len(lines[index])
+
+		relative := types.Span{Start: span.Start - lineStart, End: span.End - lineStart}
+		if span.End > lineEnd {
+			relative.End = lineEnd - lineStart
+		}
+
+		if n := len(matches); n > 0 && matches[n-1].Line == index+1 {
+			matches[n-1].Spans = append(matches[n-1].Spans, relative)
+			continue
+		}
+
+		matches = append(matches, LineMatch{
+			File:   file,
+			Line:   index + 1,
+			Column: relative.Start + 1,
+			Text:   strings.TrimRight(lines[index], "\r"),
+			Offset: span.Start,
+			Spans:  []types.Span{relative},
+			Before: contextLines(lines, index-context, index),
+			After:  contextLines(lines, index+1, index+1+context),
+		})
+	}
+	return matches
+}
+
+func contextLines(lines []string, from, to int) []string {
+	if from < 0 {
+		from = 0
+	}
+	if to > len(lines) {
+		to = len(lines)
+	}
+	result := make([]string, 0, to-from)
+	for _, line := range lines[from:to] {
+		result = append(result, strings.TrimRight(line, "\r"))
+	}
+	return result
+}
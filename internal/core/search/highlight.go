@@ -0,0 +1,99 @@
+package search
+
+import (
+	"regexp"
+	"sort"
+
+	"rules-explorer/internal/core/types"
+)
+
+// highlighter is implemented by nodes that can locate their matches in content
+type highlighter interface {
+	spans(content string) []types.Span
+}
+
+// Highlights returns the content ranges matched by the positive terms of the
+// query, sorted and merged. Negated terms are never highlighted.
+func (q *Query) Highlights(content string) []types.Span {
+	if q.Empty() || content == "" {
+		return nil
+	}
+
+	spans := make([]types.Span, 0)
+	collectSpans(q.root, content, &spans)
+	return mergeSpans(spans)
+}
+
+func collectSpans(n node, content string, spans *[]types.Span) {
+	switch v := n.(type) {
+	case *andNode:
+		for _, child := range v.children {
+			collectSpans(child, content, spans)
+		}
+	case *orNode:
+		for _, child := range v.children {
+			collectSpans(child, content, spans)
+		}
+	case *notNode:
+		// Negated terms never match the shown content
+	case highlighter:
+		*spans = append(*spans, v.spans(content)...)
+	}
+}
+
+func (n *textNode) spans(content string) []types.Span {
+	return regexSpans(n.highlight, content)
+}
+
+func (n *fieldNode) spans(content string) []types.Span {
+	return regexSpans(n.highlight, content)
+}
+
+func (n *regexNode) spans(content string) []types.Span {
+	return regexSpans(n.re, content)
+}
+
+// literalRegexp matches value case-insensitively. A regexp is used rather
+// than lowercasing so that offsets stay valid for the original text.
+func literalRegexp(value string) *regexp.Regexp {
+	if value == "" {
+		return nil
+	}
+	return regexp.MustCompile("(?i)" + regexp.QuoteMeta(value))
+}
+
+func regexSpans(re *regexp.Regexp, content string) []types.Span {
+	if re == nil {
+		return nil
+	}
+	spans := make([]types.Span, 0)
+	for _, loc := range re.FindAllStringIndex(content, -1) {
+		if loc[1] > loc[0] {
+			spans = append(spans, types.Span{Start: loc[0], End: loc[1]})
+		}
+	}
+	return spans
+}
+
+func mergeSpans(spans []types.Span) []types.Span {
+	if len(spans) == 0 {
+		return nil
+	}
+
+	sort.Slice(spans, func(i, j int) bool {
+		return spans[i].Start < spans[j].Start
+	})
+
+	merged := []types.Span{spans[0]}
+	for _, span := range spans[1:] {
+		last := &merged[len(merged)-1]
+		if span.Start <= last.End {
+			if span.End > last.End {
+				last.End = span.End
+			}
+			continue
+		}
+		merged = append(merged, span)
+	}
+	return merged
+}
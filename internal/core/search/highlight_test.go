@@ -0,0 +1,55 @@
+package search
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"rules-explorer/internal/core/types"
+)
+
+func TestHighlights(t *testing.T) {
+	content := "Prefer tabs. Never use TABS in YAML; use spaces."
+
+	tests := []struct {
+		query string
+		want  []types.Span
+	}{
+		{"", nil},
+		{"tabs", []types.Span{{Start: 7, End: 11}, {Start: 23, End: 27}}},
+		{"-tabs yaml", []types.Span{{Start: 31, End: 35}}},
+		{`"use spaces"`, []types.Span{{Start: 37, End: 47}}},
+		{"content:never path:never", []types.Span{{Start: 13, End: 18}}},
+		// overlapping matches are merged
+		{"tab tabs", []types.Span{{Start: 7, End: 11}, {Start: 23, End: 27}}},
+		{"/use \\w+/", []types.Span{{Start: 19, End: 27}, {Start: 37, End: 47}}},
+	}
+
+	for _, tt := range tests {
+		q, err := ParseQuery(tt.query, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := q.Highlights(content); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Highlights(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+// Explorer.Highlights runs under a read lock, so a query must be safe to
+// highlight from several goroutines at once (run with -race)
+func TestHighlightsConcurrent(t *testing.T) {
+	q, err := ParseQuery(`tabs "use spaces" content:yaml`, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			q.Highlights("tabs and yaml, use spaces")
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,248 @@
+package search
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"rules-explorer/internal/core/types"
+)
+
+// Index is an in-memory trigram index over lowercased file content. It keeps
+// the lowercased text of every file so queries don't re-lower it on each
+// keystroke, and narrows content matching to files that contain every
+// trigram of a term. Paths, labels and metadata are small and still checked
+// directly.
+type Index struct {
+	mu       sync.RWMutex
+	docs     map[string]*indexedDoc
+	postings map[trigram][]int
+	nextID   int
+
+	cacheMu sync.Mutex
+	cache   map[string]docSet
+}
+
+type trigram uint32
+
+type indexedDoc struct {
+	id           int
+	content      string
+	lowerPath    string
+	lowerContent string
+	trigrams     []trigram
+}
+
+// docSet is a bitset of document ids
+type docSet []uint64
+
+func (s docSet) has(id int) bool {
+	word := id / 64
+	return word < len(s) && s[word]&(1<<(uint(id)%64)) != 0
+}
+
+func NewIndex() *Index {
+	return &Index{
+		docs:     make(map[string]*indexedDoc),
+		postings: make(map[trigram][]int),
+		cache:    make(map[string]docSet),
+	}
+}
+
+// Add indexes file, replacing any previous version with the same path.
+// Unchanged files are left alone.
+func (idx *Index) Add(file types.FileItem) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.add(file)
+}
+
+// Remove drops the file at path from the index
+func (idx *Index) Remove(path string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.remove(path)
+}
+
+// Sync brings the index in line with files: new and changed files are
+// (re)indexed and files that are gone are removed.
+func (idx *Index) Sync(files []types.FileItem) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	seen := make(map[string]bool, len(files))
+	for _, file := range files {
+		seen[file.Path] = true
+		idx.add(file)
+	}
+	for path := range idx.docs {
+		if !seen[path] {
+			idx.remove(path)
+		}
+	}
+}
+
+func (idx *Index) Len() int {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return len(idx.docs)
+}
+
+func (idx *Index) add(file types.FileItem) {
+	if doc, ok := idx.docs[file.Path]; ok {
+		if doc.content == file.Content {
+			return
+		}
+		idx.remove(file.Path)
+	}
+
+	lower := strings.ToLower(file.Content)
+	doc := &indexedDoc{
+		id:           idx.nextID,
+		content:      file.Content,
+		lowerPath:    strings.ToLower(file.Path),
+		lowerContent: lower,
+		trigrams:     trigrams(lower),
+	}
+	idx.nextID++
+	idx.docs[file.Path] = doc
+
+	// Ids only grow, so appending keeps every posting list sorted
+	for _, t := range doc.trigrams {
+		idx.postings[t] = append(idx.postings[t], doc.id)
+	}
+	idx.invalidate()
+}
+
+func (idx *Index) remove(path string) {
+	doc, ok := idx.docs[path]
+	if !ok {
+		return
+	}
+	delete(idx.docs, path)
+
+	for _, t := range doc.trigrams {
+		ids := idx.postings[t]
+		i := sort.SearchInts(ids, doc.id)
+		if i < len(ids) && ids[i] == doc.id {
+			ids = append(ids[:i], ids[i+1:]...)
+		}
+		if len(ids) == 0 {
+			delete(idx.postings, t)
+		} else {
+			idx.postings[t] = ids
+		}
+	}
+	idx.invalidate()
+}
+
+func (idx *Index) invalidate() {
+	idx.cacheMu.Lock()
+	if len(idx.cache) > 0 {
+		idx.cache = make(map[string]docSet)
+	}
+	idx.cacheMu.Unlock()
+}
+
+// lookup returns the indexed version of file, or nil when the index doesn't
+// hold this exact content
+func (idx *Index) lookup(file types.FileItem) *indexedDoc {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	doc, ok := idx.docs[file.Path]
+	if !ok || doc.content != file.Content {
+		return nil
+	}
+	return doc
+}
+
+// candidates returns the documents whose content may contain term, which
+// must already be lowercased. ok is false when term is too short to narrow.
+func (idx *Index) candidates(term string) (docSet, bool) {
+	if len(term) < 3 {
+		return nil, false
+	}
+
+	// The read lock is held until the set is cached, so an Add or Sync can't
+	// invalidate the cache between computing the set and storing it
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	idx.cacheMu.Lock()
+	set, cached := idx.cache[term]
+	idx.cacheMu.Unlock()
+	if cached {
+		return set, true
+	}
+
+	set = idx.intersect(trigrams(term))
+
+	idx.cacheMu.Lock()
+	idx.cache[term] = set
+	idx.cacheMu.Unlock()
+	return set, true
+}
+
+func (idx *Index) intersect(terms []trigram) docSet {
+	lists := make([][]int, 0, len(terms))
+	for _, t := range terms {
+		ids := idx.postings[t]
+		if len(ids) == 0 {
+			return docSet{}
+		}
+		lists = append(lists, ids)
+	}
+
+	// Start from the rarest trigram so the running set stays small
+	sort.Slice(lists, func(i, j int) bool { return len(lists[i]) < len(lists[j]) })
+	result := lists[0]
+	for _, ids := range lists[1:] {
+		result = intersectSorted(result, ids)
+		if len(result) == 0 {
+			break
+		}
+	}
+
+	set := make(docSet, (idx.nextID+63)/64)
+	for _, id := range result {
+		set[id/64] |= 1 << (uint(id) % 64)
+	}
+	return set
+}
+
+func intersectSorted(a, b []int) []int {
+	out := make([]int, 0, len(a))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			out = append(out, a[i])
+			i++
+			j++
+		}
+	}
+	return out
+}
+
+// trigrams returns the distinct byte trigrams of s, sorted
+func trigrams(s string) []trigram {
+	if len(s) < 3 {
+		return nil
+	}
+
+	seen := make(map[trigram]struct{})
+	for i := 0; i+3 <= len(s); i++ {
+		seen[trigram(s[i])<<16|trigram(s[i+1])<<8|trigram(s[i+2])] = struct{}{}
+	}
+
+	out := make([]trigram, 0, len(seen))
+	for t := range seen {
+		out = append(out, t)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
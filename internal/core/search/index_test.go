@@ -0,0 +1,59 @@
+package search
+
+import (
+	"fmt"
+	"testing"
+
+	"rules-explorer/internal/core/types"
+)
+
+func paths(files []types.FileItem) []string {
+	out := make([]string, len(files))
+	for i, file := range files {
+		out[i] = file.Path
+	}
+	return out
+}
+
+func checkIndexMatchesScan(t *testing.T, step string, files []types.FileItem, idx *Index) {
+	t.Helper()
+	for _, q := range benchQueries {
+		plain := benchFilter(t, q.query, q.regex, nil)
+		indexed := benchFilter(t, q.query, q.regex, idx)
+		got, want := paths(indexed.FilterFiles(files)), paths(plain.FilterFiles(files))
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("%s: %s: indexed search found %v, full scan %v", step, q.name, got, want)
+		}
+	}
+}
+
+func TestIndexMatchesScan(t *testing.T) {
+	files := benchCorpus(200, 1024)
+	idx := NewIndex()
+	idx.Sync(files)
+	checkIndexMatchesScan(t, "initial", files, idx)
+
+	// Every query has run once, so the candidate cache is warm for the edits
+	// below
+	added := types.FileItem{
+		Path:    "new/CLAUDE.md",
+		Content: "Migration rule4242 needs error handling, retry timeout and a Kubernetes schema.",
+		Label:   "claude",
+	}
+	files = append(files, added)
+	idx.Add(added)
+	checkIndexMatchesScan(t, "add", files, idx)
+
+	files[1].Content = "Always kubernetes; never error handling.\n"
+	idx.Add(files[1])
+	checkIndexMatchesScan(t, "edit", files, idx)
+
+	idx.Remove(files[2].Path)
+	files = append(files[:2], files[3:]...)
+	checkIndexMatchesScan(t, "remove", files, idx)
+
+	files[0].Content += "\nkubernetes migration schema"
+	files = files[:len(files)-5]
+	idx.Sync(files)
+	checkIndexMatchesScan(t, "sync", files, idx)
+}
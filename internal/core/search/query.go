@@ -0,0 +1,697 @@
+package search
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"rules-explorer/internal/core/glob"
+	"rules-explorer/internal/core/resolver"
+	"rules-explorer/internal/core/types"
+)
+
+// Query is a parsed search expression:
+//
+//	type:cursor glob:*.ts -deprecated "error handling" OR logging
+//
+// Terms separated by whitespace (or AND) must all match, OR separates
+// alternatives and binds looser than AND, "-" or NOT negates a term and
+// parentheses group. Terms may be qualified with a field: type, path, name,
+// content, glob, or a frontmatter key such as alwaysApply or description.
+// A /pattern/ term (or /pattern/i) is a regular expression matched against
+// the path and content.
+type Query struct {
+	root node
+}
+
+// SyntaxError describes a query that could not be parsed.
+type SyntaxError struct {
+	Pos int
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("column %d: %s", e.Pos+1, e.Msg)
+}
+
+// qualifiers are the fields every query understands
+var qualifiers = map[string]bool{
+	"type": true, "path": true, "name": true, "content": true, "glob": true, "globs": true, "mode": true,
+}
+
+// FieldNames returns the lowercased frontmatter keys used by files, which
+// ParseQuery accepts as fields
+func FieldNames(files []types.FileItem) map[string]bool {
+	fields := make(map[string]bool)
+	for _, file := range files {
+		if file.Metadata == nil {
+			continue
+		}
+		for _, key := range file.Metadata.Keys {
+			fields[strings.ToLower(key)] = true
+		}
+	}
+	return fields
+}
+
+// ParseRegexQuery treats the whole query as one regular expression.
+func ParseRegexQuery(query string) (*Query, error) {
+	if query == "" {
+		return &Query{}, nil
+	}
+	re, err := CompileRegex(query, false)
+	if err != nil {
+		return nil, err
+	}
+	return &Query{root: &regexNode{re: re}}, nil
+}
+
+// ParseQuery parses query. An empty query matches every file. A "word:" is
+// a field when word is a qualifier or one of fields (lowercased frontmatter
+// keys, see FieldNames); otherwise, like "TODO:", it is plain text.
+func ParseQuery(query string, fields map[string]bool) (*Query, error) {
+	tokens, err := lex(query, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	if len(tokens) == 0 {
+		return &Query{}, nil
+	}
+
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		tok := p.tokens[p.pos]
+		return nil, &SyntaxError{Pos: tok.pos, Msg: fmt.Sprintf("unexpected %q", tok.text)}
+	}
+
+	return &Query{root: root}, nil
+}
+
+func (q *Query) Empty() bool {
+	return q == nil || q.root == nil
+}
+
+// Score evaluates the query against a file. Higher scores rank first.
+func (q *Query) Score(file types.FileItem) (int, bool) {
+	return q.ScoreIndexed(file, nil)
+}
+
+// ScoreIndexed is Score using idx (which may be nil) to skip content scans
+// for files that can't contain a term.
+func (q *Query) ScoreIndexed(file types.FileItem, idx *Index) (int, bool) {
+	if q.Empty() {
+		return 0, true
+	}
+	doc := &document{file: file}
+	if idx != nil {
+		if indexed := idx.lookup(file); indexed != nil {
+			doc.index = idx
+			doc.indexed = indexed
+		}
+	}
+	return q.root.eval(doc)
+}
+
+// document caches lowercased fields while a query is evaluated
+type document struct {
+	file         types.FileItem
+	lowerPath    string
+	lowerContent string
+	loweredPath  bool
+	loweredBody  bool
+	index        *Index
+	indexed      *indexedDoc
+	// negated is set while evaluating under NOT, where a loose fuzzy match
+	// must not be enough to exclude a file
+	negated bool
+}
+
+// mayContain reports whether the lowercased content may contain term. It is
+// only false when the index rules the file out.
+func (d *document) mayContain(term string) bool {
+	if d.indexed == nil {
+		return true
+	}
+	set, ok := d.index.candidates(term)
+	return !ok || set.has(d.indexed.id)
+}
+
+func (d *document) path() string {
+	if d.indexed != nil {
+		return d.indexed.lowerPath
+	}
+	if !d.loweredPath {
+		d.lowerPath = strings.ToLower(d.file.Path)
+		d.loweredPath = true
+	}
+	return d.lowerPath
+}
+
+func (d *document) content() string {
+	if d.indexed != nil {
+		return d.indexed.lowerContent
+	}
+	if !d.loweredBody {
+		d.lowerContent = strings.ToLower(d.file.Content)
+		d.loweredBody = true
+	}
+	return d.lowerContent
+}
+
+type node interface {
+	eval(doc *document) (int, bool)
+}
+
+type andNode struct {
+	children []node
+}
+
+func (n *andNode) eval(doc *document) (int, bool) {
+	total := 0
+	scored := 0
+	for _, child := range n.children {
+		score, ok := child.eval(doc)
+		if !ok {
+			return 0, false
+		}
+		if _, negated := child.(*notNode); !negated {
+			total += score
+			scored++
+		}
+	}
+	if scored == 0 {
+		return 0, true
+	}
+	return total / scored, true
+}
+
+type orNode struct {
+	children []node
+}
+
+func (n *orNode) eval(doc *document) (int, bool) {
+	best := 0
+	matched := false
+	for _, child := range n.children {
+		if score, ok := child.eval(doc); ok && (!matched || score > best) {
+			best = score
+			matched = true
+		}
+	}
+	return best, matched
+}
+
+type notNode struct {
+	child node
+}
+
+func (n *notNode) eval(doc *document) (int, bool) {
+	doc.negated = !doc.negated
+	_, ok := n.child.eval(doc)
+	doc.negated = !doc.negated
+	return 0, !ok
+}
+
+// textNode is an unqualified word or phrase
+type textNode struct {
+	value  string
+	phrase bool
+	// highlight is compiled when the query is parsed, so that concurrent
+	// Highlights calls only read it
+	highlight *regexp.Regexp
+}
+
+func (n *textNode) eval(doc *document) (int, bool) {
+	if n.phrase {
+		if strings.Contains(doc.path(), n.value) {
+			return tierPath, true
+		}
+	} else if strings.Contains(doc.path(), n.value) || (!doc.negated && fuzzyBaseName(n.value, doc.file.Path)) {
+		// Fuzzy matches across directories would accept nearly every path
+		// in a deep tree, so only the base name is matched loosely
+		score, _, _ := FuzzyMatch(n.value, doc.file.Path)
+		return tierPath + score, true
+	}
+
+	if strings.Contains(doc.file.Label, n.value) || matchMetadata(doc.file.Metadata, n.value) {
+		return tierMetadata, true
+	}
+
+	if !doc.mayContain(n.value) {
+		return 0, false
+	}
+	count := strings.Count(doc.content(), n.value)
+	if count == 0 {
+		return 0, false
+	}
+	if count > 100 {
+		count = 100
+	}
+	return tierContent + count, true
+}
+
+func fuzzyBaseName(term, filePath string) bool {
+	_, _, ok := FuzzyMatch(term, path.Base(filePath))
+	return ok
+}
+
+func matchMetadata(metadata *types.Metadata, term string) bool {
+	if metadata == nil {
+		return false
+	}
+
+	if strings.Contains(strings.ToLower(metadata.Description), term) ||
+		strings.Contains(strings.ToLower(metadata.Mode().String()), term) {
+		return true
+	}
+
+	for _, g := range metadata.Globs {
+		if strings.Contains(strings.ToLower(g), term) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// regexNode matches a regular expression against the path and content
+type regexNode struct {
+	re *regexp.Regexp
+}
+
+func (n *regexNode) eval(doc *document) (int, bool) {
+	if n.re.MatchString(doc.file.Path) {
+		return tierPath, true
+	}
+
+	// Any match starts with the literal prefix, so it can narrow too
+	if prefix, _ := n.re.LiteralPrefix(); !doc.mayContain(strings.ToLower(prefix)) {
+		return 0, false
+	}
+
+	matches := n.re.FindAllStringIndex(doc.file.Content, 100)
+	if len(matches) == 0 {
+		return 0, false
+	}
+	return tierContent + len(matches), true
+}
+
+// CompileRegex compiles a search regex. A trailing "i" flag (as in /re/i)
+// is passed in as ignoreCase.
+func CompileRegex(pattern string, ignoreCase bool) (*regexp.Regexp, error) {
+	if ignoreCase {
+		pattern = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regex: %s", strings.TrimPrefix(err.Error(), "error parsing regexp: "))
+	}
+	return re, nil
+}
+
+// fieldNode is a field:value qualifier
+type fieldNode struct {
+	field string
+	value string
+	// highlight is only set for content: fields; the others don't match
+	// the shown content
+	highlight *regexp.Regexp
+}
+
+func (n *fieldNode) eval(doc *document) (int, bool) {
+	switch strings.ToLower(n.field) {
+	case "type":
+		return tierMetadata, matchType(doc.file, n.value)
+	case "path":
+		return tierPath, matchText(doc.path(), n.value)
+	case "name":
+		return tierPath, matchText(strings.ToLower(path.Base(doc.file.Path)), n.value)
+	case "content":
+		return tierContent, doc.mayContain(n.value) && strings.Contains(doc.content(), n.value)
+	case "glob", "globs":
+		return tierMetadata, matchGlobField(doc.file.Metadata, n.value)
+	case "mode":
+		return tierMetadata, doc.file.Metadata != nil &&
+			strings.Contains(strings.ToLower(doc.file.Metadata.Mode().String()), n.value)
+	}
+
+	metadata := doc.file.Metadata
+	if metadata == nil {
+		return 0, false
+	}
+	for key, raw := range metadata.Fields {
+		if !strings.EqualFold(key, n.field) {
+			continue
+		}
+		raw = strings.ToLower(raw)
+		if isBool(n.value) {
+			return tierMetadata, raw == n.value || (raw == "" && n.value == "false")
+		}
+		return tierMetadata, strings.Contains(raw, n.value)
+	}
+	// A missing boolean flag reads as false
+	return tierMetadata, n.value == "false"
+}
+
+func matchType(file types.FileItem, value string) bool {
+	if strings.EqualFold(file.Label, value) {
+		return true
+	}
+	// A built-in label names exactly one type; other values match type
+	// names loosely
+	if fileType := types.ParseFileType(strings.ToLower(value)); fileType != types.Unknown {
+		return file.Type() == fileType
+	}
+	name := strings.ToLower(strings.ReplaceAll(file.Type().String(), " ", ""))
+	return strings.Contains(name, strings.ReplaceAll(value, " ", ""))
+}
+
+func matchText(text, value string) bool {
+	if glob.HasMeta(value) {
+		return glob.Match(value, text) || glob.Match("**/"+value, text)
+	}
+	return strings.Contains(text, value)
+}
+
+// matchGlobField matches rules whose globs mention value, or, when value is a
+// plain path, rules whose globs would attach to it
+func matchGlobField(metadata *types.Metadata, value string) bool {
+	if metadata == nil {
+		return false
+	}
+	for _, g := range metadata.Globs {
+		if strings.Contains(strings.ToLower(g), value) {
+			return true
+		}
+	}
+	if !glob.HasMeta(value) {
+		_, ok := resolver.MatchGlobs(metadata, "", value)
+		return ok
+	}
+	return false
+}
+
+func isBool(value string) bool {
+	return value == "true" || value == "false"
+}
+
+type tokenKind int
+
+const (
+	tokenWord tokenKind = iota
+	tokenPhrase
+	tokenField
+	tokenLParen
+	tokenRParen
+	tokenOr
+	tokenAnd
+	tokenNot
+	tokenRegex
+)
+
+type token struct {
+	kind   tokenKind
+	text   string
+	field  string
+	negate bool
+	pos    int
+}
+
+func lex(query string, fields map[string]bool) ([]token, error) {
+	tokens := make([]token, 0)
+	runes := []rune(query)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+			continue
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "(", pos: i})
+			i++
+			continue
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")", pos: i})
+			i++
+			continue
+		}
+
+		start := i
+		if r == '-' && i+1 < len(runes) && runes[i+1] == '(' {
+			tokens = append(tokens, token{kind: tokenNot, text: "-", pos: i})
+			i++
+			continue
+		}
+
+		negate := false
+		if r == '-' && i+1 < len(runes) && !unicode.IsSpace(runes[i+1]) {
+			negate = true
+			i++
+		}
+
+		if i < len(runes) && runes[i] == '"' {
+			value, next, err := readPhrase(runes, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokenPhrase, text: value, negate: negate, pos: start})
+			i = next
+			continue
+		}
+
+		if i < len(runes) && runes[i] == '/' {
+			if pattern, flags, next, ok := readRegex(runes, i); ok {
+				tokens = append(tokens, token{kind: tokenRegex, text: pattern, field: flags, negate: negate, pos: start})
+				i = next
+				continue
+			}
+		}
+
+		wordStart := i
+		for i < len(runes) && !unicode.IsSpace(runes[i]) && runes[i] != '(' && runes[i] != ')' && runes[i] != '"' {
+			i++
+		}
+		word := string(runes[wordStart:i])
+
+		if !negate {
+			switch word {
+			case "OR", "||":
+				tokens = append(tokens, token{kind: tokenOr, text: word, pos: start})
+				continue
+			case "AND", "&&":
+				tokens = append(tokens, token{kind: tokenAnd, text: word, pos: start})
+				continue
+			case "NOT":
+				tokens = append(tokens, token{kind: tokenNot, text: word, pos: start})
+				continue
+			}
+		}
+
+		// URLs such as https://... are plain words, not fields
+		if field, value, ok := strings.Cut(word, ":"); ok && isField(field, fields) && !strings.HasPrefix(value, "//") {
+			if value == "" && i < len(runes) && runes[i] == '"' {
+				phrase, next, err := readPhrase(runes, i)
+				if err != nil {
+					return nil, err
+				}
+				value = phrase
+				i = next
+			}
+			if value == "" {
+				return nil, &SyntaxError{Pos: start, Msg: fmt.Sprintf("missing value for %s:", field)}
+			}
+			tokens = append(tokens, token{kind: tokenField, field: field, text: value, negate: negate, pos: start})
+			continue
+		}
+
+		if word == "" {
+			return nil, &SyntaxError{Pos: start, Msg: "expected a term after -"}
+		}
+		tokens = append(tokens, token{kind: tokenWord, text: word, negate: negate, pos: start})
+	}
+
+	return tokens, nil
+}
+
+func readPhrase(runes []rune, start int) (string, int, error) {
+	for i := start + 1; i < len(runes); i++ {
+		if runes[i] == '"' {
+			return string(runes[start+1 : i]), i + 1, nil
+		}
+	}
+	return "", 0, &SyntaxError{Pos: start, Msg: "unterminated quoted phrase"}
+}
+
+// readRegex reads a /pattern/ or /pattern/i literal starting at start. The
+// pattern may contain spaces and escaped slashes. ok is false when there is
+// no closing slash, so that a plain word like "/tmp" stays a word.
+func readRegex(runes []rune, start int) (pattern string, flags string, next int, ok bool) {
+	for i := start + 1; i < len(runes); i++ {
+		switch runes[i] {
+		case '\\':
+			i++
+		case '/':
+			if i == start+1 {
+				return "", "", 0, false
+			}
+			end := i + 1
+			for end < len(runes) && runes[end] == 'i' {
+				end++
+			}
+			if end < len(runes) && !unicode.IsSpace(runes[end]) && runes[end] != ')' {
+				return "", "", 0, false
+			}
+			pattern = strings.ReplaceAll(string(runes[start+1:i]), `\/`, "/")
+			return pattern, string(runes[i+1 : end]), end, true
+		}
+	}
+	return "", "", 0, false
+}
+
+func isField(name string, fields map[string]bool) bool {
+	name = strings.ToLower(name)
+	return qualifiers[name] || fields[name]
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() (token, bool) {
+	if p.pos >= len(p.tokens) {
+		return token{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+func (p *parser) parseOr() (node, error) {
+	first, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	children := []node{first}
+	for {
+		tok, ok := p.peek()
+		if !ok || tok.kind != tokenOr {
+			break
+		}
+		p.pos++
+		next, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, next)
+	}
+
+	if len(children) == 1 {
+		return first, nil
+	}
+	return &orNode{children: children}, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	children := make([]node, 0)
+	for {
+		tok, ok := p.peek()
+		if !ok || tok.kind == tokenOr || tok.kind == tokenRParen {
+			break
+		}
+		if tok.kind == tokenAnd {
+			if len(children) == 0 {
+				return nil, &SyntaxError{Pos: tok.pos, Msg: "AND needs a term on its left"}
+			}
+			p.pos++
+			continue
+		}
+		child, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, child)
+	}
+
+	if len(children) == 0 {
+		pos := len(p.tokens)
+		if tok, ok := p.peek(); ok {
+			pos = tok.pos
+		} else if len(p.tokens) > 0 {
+			pos = p.tokens[len(p.tokens)-1].pos
+		}
+		return nil, &SyntaxError{Pos: pos, Msg: "expected a search term"}
+	}
+	if len(children) == 1 {
+		return children[0], nil
+	}
+	return &andNode{children: children}, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	tok, _ := p.peek()
+	p.pos++
+
+	switch tok.kind {
+	case tokenNot:
+		if _, ok := p.peek(); !ok {
+			return nil, &SyntaxError{Pos: tok.pos, Msg: "NOT needs a term"}
+		}
+		child, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{child: child}, nil
+	case tokenLParen:
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		closing, ok := p.peek()
+		if !ok || closing.kind != tokenRParen {
+			return nil, &SyntaxError{Pos: tok.pos, Msg: "unclosed parenthesis"}
+		}
+		p.pos++
+		return inner, nil
+	case tokenWord, tokenPhrase, tokenField:
+		return wrapNegation(tokenNode(tok), tok.negate), nil
+	case tokenRegex:
+		re, err := CompileRegex(tok.text, tok.field != "")
+		if err != nil {
+			return nil, &SyntaxError{Pos: tok.pos, Msg: err.Error()}
+		}
+		return wrapNegation(&regexNode{re: re}, tok.negate), nil
+	}
+
+	return nil, &SyntaxError{Pos: tok.pos, Msg: fmt.Sprintf("unexpected %q", tok.text)}
+}
+
+func tokenNode(tok token) node {
+	value := strings.ToLower(tok.text)
+	switch tok.kind {
+	case tokenField:
+		n := &fieldNode{field: tok.field, value: value}
+		if n.field == "content" {
+			n.highlight = literalRegexp(value)
+		}
+		return n
+	case tokenPhrase:
+		return &textNode{value: value, phrase: true, highlight: literalRegexp(value)}
+	default:
+		return &textNode{value: value, highlight: literalRegexp(value)}
+	}
+}
+
+func wrapNegation(n node, negate bool) node {
+	if negate {
+		return &notNode{child: n}
+	}
+	return n
+}
@@ -0,0 +1,173 @@
+package search
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"rules-explorer/internal/core/types"
+)
+
+func TestParseQuerySyntaxErrors(t *testing.T) {
+	tests := []struct {
+		query string
+		pos   int
+		msg   string
+	}{
+		{`"error handling`, 0, "unterminated quoted phrase"},
+		{"type:", 0, "missing value for type:"},
+		{"a type: b", 2, "missing value for type:"},
+		{"(a OR b", 0, "unclosed parenthesis"},
+		{"a)", 1, `unexpected ")"`},
+		{"AND a", 0, "AND needs a term on its left"},
+		{"a NOT", 2, "NOT needs a term"},
+		{"a -)", 2, "expected a term after -"},
+		{"a OR", 2, "expected a search term"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := ParseQuery(tt.query, nil)
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("ParseQuery(%q) error = %v, want a SyntaxError", tt.query, err)
+			}
+			if syntaxErr.Pos != tt.pos || syntaxErr.Msg != tt.msg {
+				t.Errorf("ParseQuery(%q) = %d %q, want %d %q", tt.query, syntaxErr.Pos, syntaxErr.Msg, tt.pos, tt.msg)
+			}
+		})
+	}
+}
+
+func TestQueryMatches(t *testing.T) {
+	files := []types.FileItem{
+		{Path: "a.md", Content: "alpha beta"},
+		{Path: "b.md", Content: "beta gamma"},
+		{Path: "c.md", Content: "gamma alpha"},
+		{Path: "d.md", Content: "TODO: delta", Metadata: &types.Metadata{Keys: []string{"model"}, Fields: map[string]string{"model": "haiku"}}},
+		{Path: "the-best-rules.mdc", Content: "prefer tabs"},
+		{Path: "notes/r_u_l_e_s.md", Content: "prefer spaces"},
+	}
+	fields := FieldNames(files)
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"", "a b c d the-best-rules.mdc notes/r_u_l_e_s"},
+		{"alpha beta", "a"},
+		{"alpha AND beta", "a"},
+		{"alpha OR gamma", "a b c"},
+		// OR binds looser than AND
+		{"alpha beta OR gamma", "a b c"},
+		{"gamma OR alpha beta", "a b c"},
+		{"(alpha OR gamma) beta", "a b"},
+		{"alpha (beta OR gamma)", "a c"},
+		{"-alpha", "b d the-best-rules.mdc notes/r_u_l_e_s"},
+		{"NOT alpha", "b d the-best-rules.mdc notes/r_u_l_e_s"},
+		{"-alpha gamma", "b"},
+		{"-(alpha OR beta)", "d the-best-rules.mdc notes/r_u_l_e_s"},
+		{`"alpha beta"`, "a"},
+		{`"beta alpha"`, ""},
+		// a "word:" that is neither a qualifier nor a frontmatter key is text
+		{"TODO:", "d"},
+		{"model:haiku", "d"},
+		{"model:sonnet", ""},
+		{"name:c.md", "c"},
+		// a plain word matches base names fuzzily, but only containment
+		// counts when it is negated
+		{"test", "the-best-rules.mdc"},
+		{"-test", "a b c d the-best-rules.mdc notes/r_u_l_e_s"},
+		{"NOT test", "a b c d the-best-rules.mdc notes/r_u_l_e_s"},
+		{"-(test OR alpha)", "b d the-best-rules.mdc notes/r_u_l_e_s"},
+		{"-rules", "a b c d notes/r_u_l_e_s"},
+		{"rules", "the-best-rules.mdc notes/r_u_l_e_s"},
+		{"NOT rules", "a b c d notes/r_u_l_e_s"},
+		{"-rules prefer", "notes/r_u_l_e_s"},
+		{"prefer -best", "notes/r_u_l_e_s"},
+		{"NOT (rules OR alpha)", "b d notes/r_u_l_e_s"},
+		// double negation matches like the plain word
+		{"NOT -rules", "the-best-rules.mdc notes/r_u_l_e_s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, err := ParseQuery(tt.query, fields)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, file := range files {
+				if _, ok := q.Score(file); ok {
+					got = append(got, strings.TrimSuffix(file.Path, ".md"))
+				}
+			}
+			if strings.Join(got, " ") != tt.want {
+				t.Errorf("%q matched %q, want %q", tt.query, strings.Join(got, " "), tt.want)
+			}
+		})
+	}
+}
+
+func TestRegexQuery(t *testing.T) {
+	files := []types.FileItem{
+		{Path: "src/a.md", Content: "Always use tabs"},
+		{Path: "src/b.md", Content: "always use spaces\nnever tabs"},
+		{Path: "docs/c.md", Content: "see /tmp/notes and a/b paths"},
+	}
+
+	tests := []struct {
+		query string
+		regex bool
+		want  string
+	}{
+		{"/^always/", false, "src/b.md"},
+		{"/^always/i", false, "src/a.md src/b.md"},
+		{"/^always/ -/never/", false, ""},
+		{"/^always/i -/never/", false, "src/a.md"},
+		{"/(tabs|spaces)$/ OR /notes/", false, "src/a.md src/b.md docs/c.md"},
+		{`/a\/b/`, false, "docs/c.md"},
+		{"/use tabs/", false, "src/a.md"},
+		{"/^docs\\//", false, "docs/c.md"},
+		// without a closing slash it is a plain word
+		{"/tmp", false, "docs/c.md"},
+		{"Always( never)?", true, "src/a.md"},
+		{"^never", true, ""},
+		{"(?m)^never", true, "src/b.md"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			var q *Query
+			var err error
+			if tt.regex {
+				q, err = ParseRegexQuery(tt.query)
+			} else {
+				q, err = ParseQuery(tt.query, nil)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, file := range files {
+				if _, ok := q.Score(file); ok {
+					got = append(got, file.Path)
+				}
+			}
+			if strings.Join(got, " ") != tt.want {
+				t.Errorf("%q matched %q, want %q", tt.query, strings.Join(got, " "), tt.want)
+			}
+		})
+	}
+}
+
+func TestRegexQueryErrors(t *testing.T) {
+	for _, query := range []string{"/a(b/", "/[a-/i"} {
+		if _, err := ParseQuery(query, nil); err == nil || !strings.Contains(err.Error(), "invalid regex") {
+			t.Errorf("ParseQuery(%q) error = %v, want an invalid regex error", query, err)
+		}
+	}
+	if _, err := ParseRegexQuery("a(b"); err == nil || !strings.Contains(err.Error(), "invalid regex") {
+		t.Errorf("ParseRegexQuery error = %v, want an invalid regex error", err)
+	}
+}
@@ -0,0 +1,150 @@
+package search
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"rules-explorer/internal/core/types"
+)
+
+// Run with: go test ./internal/core/search -run '^$' -bench . -benchmem
+
+var benchWords = strings.Fields(`always never use prefer avoid error handling
+logging tests components hooks state props typescript react api database
+migration schema request response retry timeout cache config deprecated
+security secrets tokens review commit branch lint format imports exports`)
+
+// benchCorpus builds n rule files of roughly size bytes each. The same seed
+// always gives the same corpus.
+func benchCorpus(n, size int) []types.FileItem {
+	rng := rand.New(rand.NewSource(1))
+	files := make([]types.FileItem, 0, n)
+	for i := 0; i < n; i++ {
+		var b strings.Builder
+		b.WriteString("---\ndescription: generated rule\nglobs: *.ts\n---\n")
+		for b.Len() < size {
+			b.WriteString(strings.Title(benchWords[rng.Intn(len(benchWords))]))
+			for j := 0; j < 11; j++ {
+				b.WriteString(" " + benchWords[rng.Intn(len(benchWords))])
+			}
+			fmt.Fprintf(&b, " rule%d.\n", rng.Intn(n*10))
+		}
+
+		path := fmt.Sprintf("packages/pkg%03d/.cursor/rules/rule%d.mdc", i/8, i)
+		label := "cursor"
+		if i%3 == 0 {
+			path = fmt.Sprintf("packages/pkg%03d/CLAUDE.md", i)
+			label = "claude"
+		}
+		files = append(files, types.FileItem{Path: path, Content: b.String(), Label: label})
+	}
+	return files
+}
+
+var benchQueries = []struct {
+	name  string
+	query string
+	regex bool
+}{
+	{"word", "migration", false},
+	{"rare", "rule4242", false},
+	{"missing", "kubernetes", false},
+	{"phrase", `"error handling"`, false},
+	{"and", "retry timeout -deprecated", false},
+	{"field", "type:cursor content:schema", false},
+	{"regex", "Always( never)?", true},
+}
+
+func benchFilter(b testing.TB, query string, regex bool, idx *Index) *Filter {
+	f := NewFilter()
+	f.SetIndex(idx)
+	if err := f.SetRegexMode(regex); err != nil {
+		b.Fatal(err)
+	}
+	if err := f.SetQuery(query); err != nil {
+		b.Fatal(err)
+	}
+	return f
+}
+
+func BenchmarkFilterFiles(b *testing.B) {
+	for _, size := range []int{500, 2000} {
+		files := benchCorpus(size, 4096)
+		idx := NewIndex()
+		idx.Sync(files)
+
+		for _, q := range benchQueries {
+			plain := benchFilter(b, q.query, q.regex, nil)
+			indexed := benchFilter(b, q.query, q.regex, idx)
+			if got, want := len(indexed.FilterFiles(files)), len(plain.FilterFiles(files)); got != want {
+				b.Fatalf("%s: indexed search found %d files, full scan %d", q.name, got, want)
+			}
+
+			b.Run(fmt.Sprintf("files=%d/%s/scan", size, q.name), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					plain.FilterFiles(files)
+				}
+			})
+			b.Run(fmt.Sprintf("files=%d/%s/index", size, q.name), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					indexed.FilterFiles(files)
+				}
+			})
+		}
+	}
+}
+
+// BenchmarkKeystrokes replays typing a query one character at a time
+func BenchmarkKeystrokes(b *testing.B) {
+	files := benchCorpus(1000, 4096)
+	idx := NewIndex()
+	idx.Sync(files)
+	query := "error handling"
+
+	for _, mode := range []struct {
+		name string
+		idx  *Index
+	}{{"scan", nil}, {"index", idx}} {
+		b.Run(mode.name, func(b *testing.B) {
+			f := NewFilter()
+			f.SetIndex(mode.idx)
+			for i := 0; i < b.N; i++ {
+				for j := 1; j <= len(query); j++ {
+					f.SetQuery(query[:j])
+					f.FilterFiles(files)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkIndexBuild(b *testing.B) {
+	for _, size := range []int{500, 2000} {
+		files := benchCorpus(size, 4096)
+		b.Run(fmt.Sprintf("files=%d", size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				NewIndex().Sync(files)
+			}
+		})
+	}
+}
+
+// BenchmarkIndexSync measures a refresh where a handful of files changed
+func BenchmarkIndexSync(b *testing.B) {
+	files := benchCorpus(2000, 4096)
+	idx := NewIndex()
+	idx.Sync(files)
+
+	changed := make([]types.FileItem, len(files))
+	copy(changed, files)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < 5; j++ {
+			k := (i*5 + j) % len(changed)
+			changed[k].Content = fmt.Sprintf("%s\nedit %d", files[k].Content, i)
+		}
+		idx.Sync(changed)
+	}
+}
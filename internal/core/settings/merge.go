@@ -0,0 +1,127 @@
+package settings
+
+import "sort"
+
+// Value is a setting together with the scope it was taken from
+type Value struct {
+	Value string `json:"value"`
+	Scope Scope  `json:"scope"`
+	// Overrides lists the lower scopes that set a different value
+	Overrides []Scope `json:"overrides,omitempty"`
+}
+
+// EnvVar is an environment variable in the effective settings
+type EnvVar struct {
+	Name string `json:"name"`
+	Value
+}
+
+// EffectiveHook is a hook command with the event and matcher it runs for
+type EffectiveHook struct {
+	Event   string `json:"event"`
+	Matcher string `json:"matcher,omitempty"`
+	Hook
+	Scope Scope `json:"scope"`
+}
+
+// Effective is what Claude Code ends up using after merging every scope.
+// Permission rules and hooks from all scopes apply together; for single
+// values the highest scope wins.
+type Effective struct {
+	Allow       []Value         `json:"allow"`
+	Deny        []Value         `json:"deny"`
+	Ask         []Value         `json:"ask"`
+	Directories []Value         `json:"additionalDirectories"`
+	DefaultMode Value           `json:"defaultMode"`
+	Model       Value           `json:"model"`
+	Env         []EnvVar        `json:"env"`
+	Hooks       []EffectiveHook `json:"hooks"`
+}
+
+// Merge combines files ordered from lowest to highest precedence. Files that
+// failed to parse are skipped.
+func Merge(files []File) Effective {
+	var effective Effective
+	env := make(map[string]*EnvVar)
+
+	for _, file := range files {
+		s := file.Settings
+		if s == nil {
+			continue
+		}
+		effective.Allow = mergeList(effective.Allow, s.Permissions.Allow, file.Scope)
+		effective.Deny = mergeList(effective.Deny, s.Permissions.Deny, file.Scope)
+		effective.Ask = mergeList(effective.Ask, s.Permissions.Ask, file.Scope)
+		effective.Directories = mergeList(effective.Directories, s.Permissions.AdditionalDirectories, file.Scope)
+		override(&effective.DefaultMode, s.Permissions.DefaultMode, file.Scope)
+		override(&effective.Model, s.Model, file.Scope)
+
+		for name, value := range s.Env {
+			v, ok := env[name]
+			if !ok {
+				v = &EnvVar{Name: name}
+				env[name] = v
+				v.Value = Value{Value: value, Scope: file.Scope}
+				continue
+			}
+			override(&v.Value, value, file.Scope)
+		}
+
+		for _, event := range s.HookEvents() {
+			for _, matcher := range s.Hooks[event] {
+				for _, hook := range matcher.Hooks {
+					effective.Hooks = append(effective.Hooks, EffectiveHook{
+						Event:   event,
+						Matcher: matcher.Matcher,
+						Hook:    hook,
+						Scope:   file.Scope,
+					})
+				}
+			}
+		}
+	}
+
+	for _, v := range env {
+		effective.Env = append(effective.Env, *v)
+	}
+	sort.Slice(effective.Env, func(i, j int) bool { return effective.Env[i].Name < effective.Env[j].Name })
+	sort.SliceStable(effective.Hooks, func(i, j int) bool { return effective.Hooks[i].Event < effective.Hooks[j].Event })
+
+	return effective
+}
+
+// mergeList adds the rules of a higher scope, moving rules that are repeated
+// to the higher scope
+func mergeList(merged []Value, values []string, scope Scope) []Value {
+	for _, value := range values {
+		found := false
+		for i := range merged {
+			if merged[i].Value == value {
+				merged[i].Scope = scope
+				found = true
+				break
+			}
+		}
+		if !found {
+			merged = append(merged, Value{Value: value, Scope: scope})
+		}
+	}
+	return merged
+}
+
+// override sets v from a higher scope, remembering the scope it replaced
+func override(v *Value, value string, scope Scope) {
+	if value == "" {
+		return
+	}
+	if v.Value != "" && v.Value != value {
+		v.Overrides = append(v.Overrides, v.Scope)
+	}
+	*v = Value{Value: value, Scope: scope, Overrides: v.Overrides}
+}
+
+// Empty reports whether no scope set anything
+func (e Effective) Empty() bool {
+	return len(e.Allow) == 0 && len(e.Deny) == 0 && len(e.Ask) == 0 && len(e.Directories) == 0 &&
+		e.DefaultMode.Value == "" && e.Model.Value == "" && len(e.Env) == 0 && len(e.Hooks) == 0
+}
@@ -0,0 +1,141 @@
+package settings
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// describe renders values as "value@scope", with any overridden scopes in
+// brackets, so expectations read in one line
+func describe(values ...Value) string {
+	parts := make([]string, 0, len(values))
+	for _, v := range values {
+		if v.Value == "" {
+			continue
+		}
+		part := v.Value + "@" + v.Scope.String()
+		if len(v.Overrides) > 0 {
+			part += fmt.Sprint(v.Overrides)
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		name    string
+		user    string
+		project string
+		local   string
+		allow   string
+		deny    string
+		model   string
+		mode    string
+		env     string
+		hooks   string
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:    "rules from every scope apply",
+			user:    `{"permissions": {"allow": ["Read"], "deny": ["Bash(rm:*)"]}}`,
+			project: `{"permissions": {"allow": ["Bash(npm test)"]}}`,
+			local:   `{"permissions": {"allow": ["WebFetch"]}}`,
+			allow:   "Read@user Bash(npm test)@project WebFetch@local",
+			deny:    "Bash(rm:*)@user",
+		},
+		{
+			name:    "repeated rule moves to the higher scope",
+			user:    `{"permissions": {"allow": ["Read", "Edit"]}}`,
+			project: `{"permissions": {"allow": ["Edit"]}}`,
+			local:   `{"permissions": {"allow": ["Read", "Edit"]}}`,
+			allow:   "Read@local Edit@local",
+		},
+		{
+			name:    "highest scope wins",
+			user:    `{"model": "opus", "permissions": {"defaultMode": "plan"}}`,
+			project: `{"model": "sonnet"}`,
+			local:   `{"model": "haiku"}`,
+			model:   "haiku@local[user project]",
+			mode:    "plan@user",
+		},
+		{
+			name:    "same value is not an override",
+			user:    `{"model": "sonnet"}`,
+			project: `{"model": "sonnet"}`,
+			model:   "sonnet@project",
+		},
+		{
+			name:    "lower scope fills in what higher ones leave unset",
+			project: `{"model": "sonnet"}`,
+			local:   `{"permissions": {"defaultMode": "acceptEdits"}}`,
+			model:   "sonnet@project",
+			mode:    "acceptEdits@local",
+		},
+		{
+			name:    "env overrides per variable",
+			user:    `{"env": {"DEBUG": "1", "REGION": "eu"}}`,
+			project: `{"env": {"REGION": "us"}}`,
+			local:   `{"env": {"TOKEN": "x"}}`,
+			env:     "DEBUG=1@user REGION=us@project[user] TOKEN=x@local",
+		},
+		{
+			name:    "hooks from every scope run, grouped by event",
+			user:    `{"hooks": {"Stop": [{"hooks": [{"type": "command", "command": "notify"}]}], "PreToolUse": [{"matcher": "Bash", "hooks": [{"type": "command", "command": "audit"}]}]}}`,
+			project: `{"hooks": {"PreToolUse": [{"matcher": "Edit", "hooks": [{"type": "command", "command": "lint"}]}]}}`,
+			hooks:   "PreToolUse/Bash:audit@user PreToolUse/Edit:lint@project Stop:notify@user",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var files []File
+			for scope, content := range []string{tt.user, tt.project, tt.local} {
+				if content == "" {
+					continue
+				}
+				s, err := Parse(content)
+				if err != nil {
+					t.Fatal(err)
+				}
+				files = append(files, File{Scope: Scope(scope), Settings: s})
+			}
+			// A file that failed to parse is skipped
+			files = append(files, File{Scope: ScopeLocal, Err: fmt.Errorf("bad")})
+
+			effective := Merge(files)
+
+			env := make([]string, 0, len(effective.Env))
+			for _, v := range effective.Env {
+				env = append(env, v.Name+"="+describe(v.Value))
+			}
+			hooks := make([]string, 0, len(effective.Hooks))
+			for _, h := range effective.Hooks {
+				event := h.Event
+				if h.Matcher != "" {
+					event += "/" + h.Matcher
+				}
+				hooks = append(hooks, event+":"+h.Command+"@"+h.Scope.String())
+			}
+
+			for _, check := range []struct{ field, got, want string }{
+				{"allow", describe(effective.Allow...), tt.allow},
+				{"deny", describe(effective.Deny...), tt.deny},
+				{"model", describe(effective.Model), tt.model},
+				{"defaultMode", describe(effective.DefaultMode), tt.mode},
+				{"env", strings.Join(env, " "), tt.env},
+				{"hooks", strings.Join(hooks, " "), tt.hooks},
+			} {
+				if check.got != check.want {
+					t.Errorf("%s = %q, want %q", check.field, check.got, check.want)
+				}
+			}
+			if got, want := effective.Empty(), tt.name == "empty"; got != want {
+				t.Errorf("Empty() = %v, want %v", got, want)
+			}
+		})
+	}
+}
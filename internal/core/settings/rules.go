@@ -0,0 +1,78 @@
+package settings
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Rule is a permission rule: a tool name with an optional specifier, like
+// Bash(npm run test:*) or Read(./.env)
+type Rule struct {
+	Tool      string
+	Specifier string
+}
+
+// Tools lists the built-in tools permission rules can name; MCP tools are
+// written mcp__server or mcp__server__tool
+var Tools = []string{
+	"Bash", "BashOutput", "Edit", "ExitPlanMode", "Glob", "Grep", "KillShell", "LS",
+	"MultiEdit", "NotebookEdit", "NotebookRead", "Read", "SlashCommand", "Task",
+	"TodoWrite", "WebFetch", "WebSearch", "Write",
+}
+
+var ErrUnknownTool = errors.New("unknown tool")
+
+// ParseRule checks the syntax of a permission rule. An unknown tool name is
+// reported with ErrUnknownTool alongside the parsed rule, since newer
+// versions of Claude Code may add tools.
+func ParseRule(rule string) (Rule, error) {
+	if strings.TrimSpace(rule) != rule {
+		return Rule{}, errors.New("leading or trailing whitespace")
+	}
+
+	tool, specifier, hasSpecifier := strings.Cut(rule, "(")
+	if hasSpecifier {
+		if !strings.HasSuffix(specifier, ")") {
+			return Rule{}, errors.New("missing closing parenthesis")
+		}
+		specifier = strings.TrimSuffix(specifier, ")")
+		if specifier == "" {
+			return Rule{}, errors.New("empty parentheses; leave them out to match every use of the tool")
+		}
+	}
+	if tool == "" {
+		return Rule{}, errors.New("missing tool name")
+	}
+	for _, r := range tool {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-') {
+			return Rule{}, fmt.Errorf("invalid character %q in tool name", r)
+		}
+	}
+
+	parsed := Rule{Tool: tool, Specifier: specifier}
+	if strings.HasPrefix(tool, "mcp__") {
+		if hasSpecifier {
+			return Rule{}, errors.New("MCP tools don't take a specifier")
+		}
+		return parsed, nil
+	}
+
+	switch tool {
+	case "Bash":
+		if i := strings.Index(specifier, ":*"); i >= 0 && i != len(specifier)-2 {
+			return Rule{}, errors.New(":* is only allowed at the end of a Bash rule")
+		}
+	case "WebFetch":
+		if hasSpecifier && !strings.HasPrefix(specifier, "domain:") {
+			return Rule{}, errors.New("WebFetch rules are written WebFetch(domain:example.com)")
+		}
+	}
+
+	for _, known := range Tools {
+		if tool == known {
+			return parsed, nil
+		}
+	}
+	return parsed, ErrUnknownTool
+}
@@ -0,0 +1,53 @@
+package settings
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseRule(t *testing.T) {
+	tests := []struct {
+		rule      string
+		tool      string
+		specifier string
+		err       error
+		invalid   bool
+	}{
+		{rule: "Bash", tool: "Bash"},
+		{rule: "Bash(npm run test:*)", tool: "Bash", specifier: "npm run test:*"},
+		{rule: "Bash(git diff)", tool: "Bash", specifier: "git diff"},
+		{rule: "Read(./.env)", tool: "Read", specifier: "./.env"},
+		{rule: "Edit(src/**/*.ts)", tool: "Edit", specifier: "src/**/*.ts"},
+		{rule: "WebFetch(domain:example.com)", tool: "WebFetch", specifier: "domain:example.com"},
+		{rule: "mcp__github", tool: "mcp__github"},
+		{rule: "mcp__github__create_issue", tool: "mcp__github__create_issue"},
+		{rule: "Frobnicate", tool: "Frobnicate", err: ErrUnknownTool},
+		{rule: "Frobnicate(x)", tool: "Frobnicate", specifier: "x", err: ErrUnknownTool},
+
+		{rule: " Bash", invalid: true},
+		{rule: "Bash(npm test", invalid: true},
+		{rule: "Bash()", invalid: true},
+		{rule: "(npm test)", invalid: true},
+		{rule: "", invalid: true},
+		{rule: "Ba sh", invalid: true},
+		{rule: "Bash(npm:* test)", invalid: true},
+		{rule: "WebFetch(example.com)", invalid: true},
+		{rule: "mcp__github(issues)", invalid: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseRule(tt.rule)
+		if tt.invalid {
+			if err == nil || errors.Is(err, ErrUnknownTool) {
+				t.Errorf("ParseRule(%q) = %+v, %v, want a syntax error", tt.rule, got, err)
+			}
+			continue
+		}
+		if !errors.Is(err, tt.err) {
+			t.Errorf("ParseRule(%q) error = %v, want %v", tt.rule, err, tt.err)
+		}
+		if got.Tool != tt.tool || got.Specifier != tt.specifier {
+			t.Errorf("ParseRule(%q) = %+v, want tool %q specifier %q", tt.rule, got, tt.tool, tt.specifier)
+		}
+	}
+}
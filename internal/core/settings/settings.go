@@ -0,0 +1,195 @@
+// Package settings reads Claude Code settings files (.claude/settings.json
+// and friends) and merges them the way Claude Code does.
+package settings
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+type Scope int
+
+const (
+	ScopeUser Scope = iota
+	ScopeProject
+	ScopeLocal
+)
+
+func (s Scope) String() string {
+	switch s {
+	case ScopeUser:
+		return "user"
+	case ScopeProject:
+		return "project"
+	case ScopeLocal:
+		return "local"
+	default:
+		return "unknown"
+	}
+}
+
+func (s Scope) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// Settings is the part of a settings file this package understands
+type Settings struct {
+	Permissions Permissions              `json:"permissions"`
+	Env         map[string]string        `json:"env"`
+	Hooks       map[string][]HookMatcher `json:"hooks"`
+	Model       string                   `json:"model"`
+	// Keys lists every top-level key in file order, known or not
+	Keys []string `json:"-"`
+}
+
+type Permissions struct {
+	Allow                 []string `json:"allow"`
+	Deny                  []string `json:"deny"`
+	Ask                   []string `json:"ask"`
+	DefaultMode           string   `json:"defaultMode"`
+	AdditionalDirectories []string `json:"additionalDirectories"`
+}
+
+// HookMatcher runs its hooks for the tools matching Matcher; an empty matcher
+// matches every tool, and events without tools ignore it
+type HookMatcher struct {
+	Matcher string `json:"matcher"`
+	Hooks   []Hook `json:"hooks"`
+}
+
+type Hook struct {
+	Type    string `json:"type"`
+	Command string `json:"command"`
+	Timeout int    `json:"timeout,omitempty"`
+}
+
+// HookCount is the number of hook commands across every event
+func (s *Settings) HookCount() int {
+	count := 0
+	for _, matchers := range s.Hooks {
+		for _, matcher := range matchers {
+			count += len(matcher.Hooks)
+		}
+	}
+	return count
+}
+
+// HookEvents returns the events with hooks, sorted
+func (s *Settings) HookEvents() []string {
+	events := make([]string, 0, len(s.Hooks))
+	for event := range s.Hooks {
+		events = append(events, event)
+	}
+	sort.Strings(events)
+	return events
+}
+
+// Parse reads a settings file
+func Parse(content string) (*Settings, error) {
+	settings := &Settings{}
+	if strings.TrimSpace(content) == "" {
+		return settings, nil
+	}
+	if err := json.Unmarshal([]byte(content), settings); err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(content))
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+	for decoder.More() {
+		key, err := decoder.Token()
+		if err != nil {
+			break
+		}
+		settings.Keys = append(settings.Keys, key.(string))
+		var skip json.RawMessage
+		if err := decoder.Decode(&skip); err != nil {
+			break
+		}
+	}
+	return settings, nil
+}
+
+// ScopeOf reports whether path is a project settings file and its scope
+func ScopeOf(path string) (Scope, bool) {
+	path = filepath.ToSlash(path)
+	if filepath.Base(filepath.Dir(path)) != ".claude" {
+		return 0, false
+	}
+	switch filepath.Base(path) {
+	case "settings.json":
+		return ScopeProject, true
+	case "settings.local.json":
+		return ScopeLocal, true
+	}
+	return 0, false
+}
+
+// File is one settings file in the merge
+type File struct {
+	// Path is absolute; DisplayPath is relative to the project root, or
+	// home-relative for user settings
+	Path        string
+	DisplayPath string
+	Scope       Scope
+	Settings    *Settings
+	// Err is set when the file exists but can't be parsed
+	Err error
+}
+
+// Load reads the user, project and local settings for root, from lowest to
+// highest precedence. Files that don't exist are left out.
+func Load(root string) ([]File, error) {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]File, 0, 3)
+	if home, err := os.UserHomeDir(); err == nil {
+		candidates = append(candidates, File{
+			Path:        filepath.Join(home, ".claude", "settings.json"),
+			DisplayPath: "~/.claude/settings.json",
+			Scope:       ScopeUser,
+		})
+	}
+	candidates = append(candidates,
+		File{
+			Path:        filepath.Join(root, ".claude", "settings.json"),
+			DisplayPath: ".claude/settings.json",
+			Scope:       ScopeProject,
+		},
+		File{
+			Path:        filepath.Join(root, ".claude", "settings.local.json"),
+			DisplayPath: ".claude/settings.local.json",
+			Scope:       ScopeLocal,
+		})
+
+	files := make([]File, 0, len(candidates))
+	seen := make(map[string]bool)
+	for _, file := range candidates {
+		// The project is the home directory: its settings are user settings
+		if seen[file.Path] {
+			continue
+		}
+		seen[file.Path] = true
+
+		data, err := os.ReadFile(file.Path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			file.Err = err
+		} else {
+			file.Settings, file.Err = Parse(string(data))
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
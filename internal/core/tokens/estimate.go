@@ -0,0 +1,119 @@
+package tokens
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// EstimateChars is the common four-bytes-per-token rule of thumb
+func EstimateChars(text string) int {
+	return (len(text) + 3) / 4
+}
+
+// EstimateBPE approximates a byte-pair-encoding tokenizer such as cl100k. It
+// splits text the way those tokenizers pre-tokenize it (words with their
+// leading space, digit groups, punctuation runs, whitespace runs) and charges
+// each piece by length, since common short pieces are single tokens and long
+// or rare ones are split.
+func EstimateBPE(text string) int {
+	count := 0
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		switch {
+		case isWordRune(r):
+			n, end := wordPiece(text, i)
+			count += 1 + (n-1)/6
+			i = end
+		case r >= '0' && r <= '9':
+			end := i
+			for end < len(text) && text[end] >= '0' && text[end] <= '9' {
+				end++
+			}
+			// Digits are merged in groups of at most three
+			count += (end - i + 2) / 3
+			i = end
+		case r == ' ' && i+1 < len(text) && !isSpace(text[i+1]):
+			// A single space is merged into the piece that follows it
+			i++
+		case unicode.IsSpace(r):
+			end := i + size
+			for end < len(text) && isSpace(text[end]) {
+				end++
+			}
+			count++
+			i = end
+		case r < utf8.RuneSelf:
+			// Punctuation merges in pairs ("()", ":=", "**") and longer runs
+			// of one character ("---", "```") share tokens
+			end := i
+			for end < len(text) && isPunct(text[end]) {
+				end++
+			}
+			count += punctTokens(text[i:end])
+			i = end
+		default:
+			// CJK and symbols are about a token per character, emoji and
+			// other four-byte characters about two
+			if size == 4 {
+				count += 2
+			} else {
+				count++
+			}
+			i += size
+		}
+	}
+	return count
+}
+
+// wordPiece returns the length in letters of the word starting at i and
+// where it ends. A lower-to-upper case change (camelCase) ends the word.
+func wordPiece(text string, i int) (n, end int) {
+	prevLower := false
+	for end = i; end < len(text); {
+		r, size := utf8.DecodeRuneInString(text[end:])
+		if !isWordRune(r) || (prevLower && unicode.IsUpper(r)) {
+			break
+		}
+		prevLower = unicode.IsLower(r)
+		n++
+		end += size
+	}
+	return n, end
+}
+
+// isWordRune reports letters that merge into words; CJK ideographs are
+// counted one by one instead
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) && utf8.RuneLen(r) <= 2
+}
+
+func punctTokens(run string) int {
+	count := 0
+	for i := 0; i < len(run); {
+		end := i
+		for end < len(run) && run[end] == run[i] {
+			end++
+		}
+		if n := end - i; n > 2 {
+			count += (n + 3) / 4
+			i = end
+			continue
+		}
+		// Pair up differing characters
+		count++
+		i += 2
+		if i > len(run) {
+			i = len(run)
+		}
+	}
+	return count
+}
+
+func isPunct(c byte) bool {
+	return c < utf8.RuneSelf && !isSpace(c) && !(c >= '0' && c <= '9') &&
+		!(c >= 'a' && c <= 'z') && !(c >= 'A' && c <= 'Z')
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
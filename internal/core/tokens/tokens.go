@@ -0,0 +1,103 @@
+// Package tokens estimates how many model tokens a piece of text uses. Exact
+// counts depend on the model's tokenizer; the estimators here are meant for
+// comparing files and spotting the ones that use up the context window.
+package tokens
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Estimator approximates the token count of text
+type Estimator interface {
+	Count(text string) int
+}
+
+// EstimatorFunc adapts a function to an Estimator
+type EstimatorFunc func(text string) int
+
+func (f EstimatorFunc) Count(text string) int {
+	return f(text)
+}
+
+// DefaultEstimator is used until Use selects another one
+const DefaultEstimator = "bpe"
+
+var (
+	estimators = make(map[string]Estimator)
+	current    Estimator
+)
+
+func init() {
+	Register(DefaultEstimator, EstimatorFunc(EstimateBPE))
+	Register("chars", EstimatorFunc(EstimateChars))
+	current = estimators[DefaultEstimator]
+}
+
+// Register makes an estimator available to Use under name
+func Register(name string, estimator Estimator) {
+	estimators[name] = estimator
+}
+
+func Lookup(name string) (Estimator, bool) {
+	estimator, ok := estimators[name]
+	return estimator, ok
+}
+
+// Names returns the registered estimator names, sorted
+func Names() []string {
+	names := make([]string, 0, len(estimators))
+	for name := range estimators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Use selects the estimator Count uses. It is meant to be called once at
+// startup, before any counting.
+func Use(name string) error {
+	estimator, ok := Lookup(name)
+	if !ok {
+		return fmt.Errorf("unknown token estimator %q (want one of %v)", name, Names())
+	}
+	current = estimator
+	return nil
+}
+
+// Count estimates the tokens in text with the selected estimator
+func Count(text string) int {
+	return current.Count(text)
+}
+
+// Format renders a count compactly: 950, 1.2k, 34k
+func Format(n int) string {
+	switch {
+	case n < 1000:
+		return fmt.Sprint(n)
+	case n < 10000:
+		return fmt.Sprintf("%.1fk", float64(n)/1000)
+	default:
+		return fmt.Sprintf("%dk", (n+500)/1000)
+	}
+}
+
+// Budget holds the token counts above which a warning is shown. Zero
+// disables a warning.
+type Budget struct {
+	// File is the limit for a single file
+	File int
+	// Always is the limit for the files loaded into every request
+	Always int
+}
+
+// DefaultBudget is used when the config doesn't set one
+var DefaultBudget = Budget{File: 2000, Always: 8000}
+
+func (b Budget) FileExceeded(n int) bool {
+	return b.File > 0 && n > b.File
+}
+
+func (b Budget) AlwaysExceeded(n int) bool {
+	return b.Always > 0 && n > b.Always
+}
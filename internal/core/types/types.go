@@ -1,6 +1,10 @@
 package types
 
 import (
+	"context"
+	"path/filepath"
+	"strings"
+
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 )
@@ -8,6 +12,75 @@ import (
 type FileItem struct {
 	Path    string
 	Content string
+	// Label is the type label of the discovery pattern that matched the file
+	Label string
+	// Metadata is the parsed frontmatter, nil when the file has none
+	Metadata *Metadata
+}
+
+// Metadata holds frontmatter fields. The typed fields cover Cursor MDC rules;
+// Fields keeps every raw value for display and search.
+type Metadata struct {
+	Description string
+	Globs       []string
+	AlwaysApply bool
+	Keys        []string
+	Fields      map[string]string
+	// BodyLine is the first line after the frontmatter block
+	BodyLine int
+	// AllowedTools, Model and ArgumentHint are read from the frontmatter of
+	// Claude commands, subagents and skills. Subagents list their tools as "tools".
+	AllowedTools []string
+	Model        string
+	ArgumentHint string
+	// Error is set when the frontmatter is malformed
+	Error string
+}
+
+// Span is a byte range [Start, End) in file content
+type Span struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+type RuleMode int
+
+const (
+	RuleManual RuleMode = iota
+	RuleAlways
+	RuleAutoAttached
+	RuleAgentRequested
+)
+
+func (m RuleMode) String() string {
+	switch m {
+	case RuleAlways:
+		return "Always"
+	case RuleAutoAttached:
+		return "Auto Attached"
+	case RuleAgentRequested:
+		return "Agent Requested"
+	default:
+		return "Manual"
+	}
+}
+
+// Mode derives how Cursor attaches the rule: alwaysApply wins over globs,
+// and a description alone lets the agent request the rule.
+func (m *Metadata) Mode() RuleMode {
+	if m == nil {
+		return RuleManual
+	}
+	if m.AlwaysApply {
+		return RuleAlways
+	}
+	if len(m.Globs) > 0 {
+		return RuleAutoAttached
+	}
+	if m.Description != "" {
+		return RuleAgentRequested
+	}
+	return RuleManual
 }
 
 type FileType int
@@ -16,9 +89,32 @@ const (
 	CursorRule FileType = iota
 	ClaudeConfig
 	ConfigFile
+	AgentsConfig
+	GeminiConfig
+	CopilotInstructions
+	WindsurfRule
+	ClineRule
+	ClaudeCommand
+	ClaudeAgent
+	ClaudeSkill
 	Unknown
 )
 
+// KnownFileTypes lists every recognised type in display order
+var KnownFileTypes = []FileType{
+	CursorRule,
+	ClaudeConfig,
+	ConfigFile,
+	ClaudeCommand,
+	ClaudeAgent,
+	ClaudeSkill,
+	AgentsConfig,
+	GeminiConfig,
+	CopilotInstructions,
+	WindsurfRule,
+	ClineRule,
+}
+
 func (ft FileType) String() string {
 	switch ft {
 	case CursorRule:
@@ -27,11 +123,118 @@ func (ft FileType) String() string {
 		return "Claude Config"
 	case ConfigFile:
 		return "Configuration"
+	case AgentsConfig:
+		return "Agents Instructions"
+	case GeminiConfig:
+		return "Gemini Config"
+	case CopilotInstructions:
+		return "Copilot Instructions"
+	case WindsurfRule:
+		return "Windsurf Rule"
+	case ClineRule:
+		return "Cline Rule"
+	case ClaudeCommand:
+		return "Claude Command"
+	case ClaudeAgent:
+		return "Claude Subagent"
+	case ClaudeSkill:
+		return "Claude Skill"
 	default:
 		return "Unknown"
 	}
 }
 
+// ParseFileType maps a pattern type label to a FileType
+func ParseFileType(label string) FileType {
+	switch label {
+	case "cursor":
+		return CursorRule
+	case "claude":
+		return ClaudeConfig
+	case "config":
+		return ConfigFile
+	case "agents":
+		return AgentsConfig
+	case "gemini":
+		return GeminiConfig
+	case "copilot":
+		return CopilotInstructions
+	case "windsurf":
+		return WindsurfRule
+	case "cline":
+		return ClineRule
+	case "command":
+		return ClaudeCommand
+	case "subagent":
+		return ClaudeAgent
+	case "skill":
+		return ClaudeSkill
+	default:
+		return Unknown
+	}
+}
+
+// Label is the inverse of ParseFileType
+func (ft FileType) Label() string {
+	for _, label := range []string{"cursor", "claude", "config", "agents", "gemini", "copilot", "windsurf", "cline", "command", "subagent", "skill"} {
+		if ParseFileType(label) == ft {
+			return label
+		}
+	}
+	return "unknown"
+}
+
+// DetectFileType classifies a path by its well-known location
+func DetectFileType(path string) FileType {
+	path = filepath.ToSlash(path)
+	base := filepath.Base(path)
+
+	if strings.HasSuffix(path, ".mdc") {
+		return CursorRule
+	}
+	if base == "CLAUDE.md" || base == "CLAUDE.local.md" {
+		return ClaudeConfig
+	}
+	if strings.HasPrefix(path, ".claude/commands/") && strings.HasSuffix(path, ".md") {
+		return ClaudeCommand
+	}
+	if strings.HasPrefix(path, ".claude/agents/") && strings.HasSuffix(path, ".md") {
+		return ClaudeAgent
+	}
+	if strings.HasPrefix(path, ".claude/skills/") && base == "SKILL.md" {
+		return ClaudeSkill
+	}
+	if strings.HasPrefix(path, ".claude/") || base == ".mcp.json" {
+		return ConfigFile
+	}
+	if base == "AGENTS.md" {
+		return AgentsConfig
+	}
+	if base == "GEMINI.md" {
+		return GeminiConfig
+	}
+	if strings.HasSuffix(path, ".github/copilot-instructions.md") ||
+		(strings.Contains(path, ".github/instructions/") && strings.HasSuffix(path, ".instructions.md")) {
+		return CopilotInstructions
+	}
+	if base == ".windsurfrules" || strings.Contains(path, ".windsurf/rules/") {
+		return WindsurfRule
+	}
+	if base == ".clinerules" || strings.Contains(path, ".clinerules/") {
+		return ClineRule
+	}
+	return Unknown
+}
+
+// Type returns the type from the discovery pattern label, falling back to
+// path-based detection
+func (f FileItem) Type() FileType {
+	if fileType := ParseFileType(f.Label); fileType != Unknown {
+		return fileType
+	}
+	return DetectFileType(f.Path)
+}
+
 type Focus int
 
 const (
@@ -50,6 +253,18 @@ const (
 	EventRefresh
 	EventQuit
 	EventEditFile
+	EventResolveRules
+	EventClaudeHierarchy
+	EventShowImports
+	EventToggleRegex
+	EventNextMatch
+	EventPrevMatch
+	EventToggleResults
+	EventLineSelected
+	EventCancelLoad
+	EventShowDuplicates
+	EventShowConflicts
+	EventShowSettings
 )
 
 type Event struct {
@@ -62,10 +277,24 @@ type FileEvent struct {
 	Index int
 }
 
+// LineEvent selects a line in a file; Offset is the byte offset of the match
+type LineEvent struct {
+	File   FileItem
+	Line   int
+	Offset int
+}
+
 type SearchEvent struct {
 	Query string
 }
 
+// LoadProgress reports a directory walk in progress: the files found since
+// the previous report and the number of paths scanned so far
+type LoadProgress struct {
+	Files   []FileItem
+	Scanned int
+}
+
 type FocusEvent struct {
 	Focus Focus
 }
@@ -74,7 +303,12 @@ type EventHandler func(event Event)
 
 type FileExplorer interface {
 	LoadFiles() error
-	FilterFiles(filter string) []FileItem
+	LoadFilesContext(ctx context.Context, progress func(LoadProgress)) error
+	Watch(ctx context.Context, onChange func(paths []string)) error
+	FilterFiles(filter string) ([]FileItem, error)
+	FilterFilesContext(ctx context.Context, filter string) ([]FileItem, error)
+	SetRegexMode(enabled bool)
+	Highlights(content string) []Span
 	GetAllFiles() []FileItem
 }
 
@@ -105,10 +339,18 @@ type ColorScheme struct {
 }
 
 type IconSet struct {
-	CursorRule   string
-	ClaudeConfig string
-	ConfigFile   string
-	Search       string
-	File         string
-	Folder       string
-}
\ No newline at end of file
+	CursorRule    string
+	ClaudeConfig  string
+	ConfigFile    string
+	AgentsConfig  string
+	GeminiConfig  string
+	CopilotConfig string
+	WindsurfRule  string
+	ClineRule     string
+	ClaudeCommand string
+	ClaudeAgent   string
+	ClaudeSkill   string
+	Search        string
+	File          string
+	Folder        string
+}
@@ -1,93 +1,262 @@
 package file
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
-	"strings"
+	"sync"
+	"time"
+	"rules-explorer/internal/core/frontmatter"
+	"rules-explorer/internal/core/ignore"
+	"rules-explorer/internal/core/patterns"
 	"rules-explorer/internal/core/types"
 	"rules-explorer/internal/core/search"
 )
 
+// Explorer is safe for concurrent use: searches may run on a worker
+// goroutine while the UI reads the loaded files and highlights.
 type Explorer struct {
-	allFiles []types.FileItem
-	filter   *search.Filter
+	mu        sync.RWMutex
+	allFiles  []types.FileItem
+	filter    *search.Filter
+	index     *search.Index
+	regexMode bool
+	// dir is the directory to walk ("" for the working directory) and root
+	// the absolute path of the last one walked
+	dir       string
+	root      string
+	patterns  *patterns.Registry
+	noIgnore  bool
 }
 
 func NewExplorer() *Explorer {
-	return &Explorer{
+	e := &Explorer{
 		allFiles: make([]types.FileItem, 0),
 		filter:   search.NewFilter(),
+		index:    search.NewIndex(),
+		patterns: patterns.Default(),
 	}
+	e.filter.SetIndex(e.index)
+	return e
 }
 
+func (e *Explorer) SetPatterns(registry *patterns.Registry) {
+	if registry == nil {
+		registry = patterns.Default()
+	}
+	e.patterns = registry
+}
+
+// SetRoot sets the directory to walk instead of the working directory.
+// Paths stay relative to it.
+func (e *Explorer) SetRoot(dir string) {
+	e.dir = dir
+}
+
+// SetNoIgnore disables .gitignore handling and the default skip list.
+// The .git directory itself is always skipped.
+func (e *Explorer) SetNoIgnore(noIgnore bool) {
+	e.noIgnore = noIgnore
+}
+
+// progressInterval is how often LoadFilesContext reports progress
+const progressInterval = 100 * time.Millisecond
+
 func (e *Explorer) LoadFiles() error {
-	e.allFiles = make([]types.FileItem, 0)
-	cwd, err := os.Getwd()
+	return e.LoadFilesContext(context.Background(), nil)
+}
+
+// LoadFilesContext walks the working directory. With a progress callback the
+// files found so far are published as the walk goes (and passed to progress
+// in batches from the walking goroutine); without one the previous files stay
+// in place until the walk finishes. When ctx is cancelled the walk stops, the
+// files found so far are kept and ctx's error is returned.
+func (e *Explorer) LoadFilesContext(ctx context.Context, progress func(types.LoadProgress)) error {
+	files := make([]types.FileItem, 0)
+	scanned := 0
+	reported := 0
+	lastReport := time.Now()
+	report := func() {
+		if progress == nil {
+			return
+		}
+		e.mu.Lock()
+		e.allFiles = files
+		e.mu.Unlock()
+		progress(types.LoadProgress{Files: files[reported:len(files):len(files)], Scanned: scanned})
+		reported = len(files)
+		lastReport = time.Now()
+	}
+
+	cwd, err := e.rootDir()
 	if err != nil {
 		return err
 	}
 
-	err = filepath.Walk(cwd, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return nil
+	var matcher *ignore.Matcher
+	if !e.noIgnore {
+		matcher = ignore.New(cwd)
+	}
+
+	err = filepath.WalkDir(cwd, func(path string, d os.DirEntry, err error) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		scanned++
+		if time.Since(lastReport) >= progressInterval {
+			report()
 		}
 
-		if info.IsDir() {
+		if err != nil {
 			return nil
 		}
 
 		relPath, err := filepath.Rel(cwd, path)
-		if err != nil {
+		if err != nil || relPath == "." {
 			return nil
 		}
 
-		if e.matchesPattern(relPath) {
-			content, err := os.ReadFile(path)
-			if err != nil {
-				content = []byte(fmt.Sprintf("Error reading file: %v", err))
+		if d.IsDir() {
+			if e.skipDir(matcher, relPath, d.Name()) {
+				return filepath.SkipDir
 			}
+			return nil
+		}
 
-			e.allFiles = append(e.allFiles, types.FileItem{
-				Path:    relPath,
-				Content: string(content),
-			})
+		if matcher != nil && !isLocalClaudeFile(relPath) && matcher.Match(relPath, false) {
+			return nil
+		}
+
+		if pattern, ok := e.patterns.Match(relPath); ok {
+			files = append(files, readFile(cwd, relPath, pattern))
+			e.index.Add(files[len(files)-1])
 		}
 
 		return nil
 	})
 
+	// Only new and changed files were re-indexed; this drops the ones that
+	// are gone
+	e.index.Sync(files)
+
+	e.mu.Lock()
+	e.allFiles = files
+	e.root = cwd
+	e.mu.Unlock()
+	if progress != nil {
+		progress(types.LoadProgress{Files: files[reported:len(files):len(files)], Scanned: scanned})
+	}
+
 	return err
 }
 
-func (e *Explorer) matchesPattern(relPath string) bool {
-	// Match .cursor/rules/*.mdc anywhere in the tree
-	if strings.Contains(relPath, ".cursor/rules/") && strings.HasSuffix(relPath, ".mdc") {
-		// Ensure the file is directly under .cursor/rules/, not in a subdirectory
-		parts := strings.Split(relPath, ".cursor/rules/")
-		if len(parts) == 2 && !strings.Contains(parts[1], "/") {
-			return true
-		}
+func (e *Explorer) rootDir() (string, error) {
+	if e.dir == "" {
+		return os.Getwd()
 	}
-	
-	// Match CLAUDE.md anywhere
-	if filepath.Base(relPath) == "CLAUDE.md" {
-		return true
+	dir, err := filepath.Abs(e.dir)
+	if err != nil {
+		return "", err
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		return "", err
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("%s is not a directory", e.dir)
+	}
+	return dir, nil
+}
+
+func readFile(root, relPath string, pattern patterns.Pattern) types.FileItem {
+	content, err := os.ReadFile(filepath.Join(root, relPath))
+	if err != nil {
+		content = []byte(fmt.Sprintf("Error reading file: %v", err))
 	}
-	
-	// Match .claude/* (direct children only)
-	if strings.HasPrefix(relPath, ".claude/") && !strings.Contains(relPath[8:], "/") {
+
+	file := types.FileItem{
+		Path:    relPath,
+		Content: string(content),
+		Label:   pattern.Type,
+	}
+	if frontmatter.Applies(file) {
+		file.Metadata = frontmatter.ParseMetadata(file.Content)
+	}
+	return file
+}
+
+func (e *Explorer) skipDir(matcher *ignore.Matcher, relPath string, name string) bool {
+	if name == ".git" {
 		return true
 	}
+	if matcher == nil {
+		return false
+	}
+	return ignore.IsDefaultSkipDir(name) || matcher.Match(relPath, true)
+}
 
-	return false
+// isLocalClaudeFile reports whether relPath is one of Claude Code's personal
+// files, which are meant to be gitignored and so are discovered regardless
+func isLocalClaudeFile(relPath string) bool {
+	relPath = filepath.ToSlash(relPath)
+	return path.Base(relPath) == "CLAUDE.local.md" || relPath == ".claude/settings.local.json"
+}
+
+func (e *Explorer) SetRegexMode(enabled bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.regexMode = enabled
+	e.filter.SetRegexMode(enabled)
+}
+
+func (e *Explorer) FilterFiles(filter string) ([]types.FileItem, error) {
+	return e.FilterFilesContext(context.Background(), filter)
+}
+
+// FilterFilesContext filters with a fresh query so concurrent searches don't
+// share state. The query becomes current (for Highlights) only if ctx is
+// still live when it finishes, so a superseded search never replaces a newer
+// one.
+func (e *Explorer) FilterFilesContext(ctx context.Context, filter string) ([]types.FileItem, error) {
+	e.mu.RLock()
+	files := e.allFiles
+	regexMode := e.regexMode
+	e.mu.RUnlock()
+
+	query := search.NewFilter()
+	query.SetIndex(e.index)
+	query.SetRegexMode(regexMode)
+	query.SetFields(search.FieldNames(files))
+	if err := query.SetQuery(filter); err != nil {
+		return nil, err
+	}
+
+	filtered, err := query.FilterFilesContext(ctx, files)
+	if err != nil {
+		return nil, err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if regexMode == e.regexMode {
+		e.filter = query
+	}
+	return filtered, nil
 }
 
-func (e *Explorer) FilterFiles(filter string) []types.FileItem {
-	e.filter.SetQuery(filter)
-	return e.filter.FilterFiles(e.allFiles)
+func (e *Explorer) Highlights(content string) []types.Span {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.filter.Highlights(content)
 }
 
 func (e *Explorer) GetAllFiles() []types.FileItem {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
 	return e.allFiles
 }
@@ -0,0 +1,202 @@
+package file
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"rules-explorer/internal/core/ignore"
+	"rules-explorer/internal/core/types"
+	"rules-explorer/internal/watch"
+)
+
+// watchSettle is how long the watcher waits for a burst of events (an editor
+// saving, a branch checkout) to settle before applying it
+const watchSettle = 150 * time.Millisecond
+
+// Watch keeps the loaded files in sync with the directory that was loaded,
+// until ctx is cancelled. After each batch of changes onChange is called,
+// from the watching goroutine, with the paths that were added, updated or
+// removed. LoadFiles must have run first.
+func (e *Explorer) Watch(ctx context.Context, onChange func(paths []string)) error {
+	e.mu.RLock()
+	root := e.root
+	e.mu.RUnlock()
+	if root == "" {
+		return errors.New("no files loaded")
+	}
+
+	matcher := e.newMatcher(root)
+	watcher, err := e.newWatcher(root, matcher)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		defer func() { watcher.Close() }()
+
+		pending := make([]watch.Event, 0)
+		var settle <-chan time.Time
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-watcher.Events():
+				if !ok {
+					return
+				}
+				pending = append(pending, event)
+				if settle == nil {
+					settle = time.After(watchSettle)
+				}
+			case <-settle:
+				settle = nil
+				changed, reload := e.applyChanges(root, matcher, pending)
+				pending = pending[:0]
+				if reload {
+					// Ignore rules changed or events were lost
+					if err := e.LoadFiles(); err != nil {
+						continue
+					}
+					// Directories skipped under the old rules may be
+					// watched now, so the watcher starts over
+					matcher = e.newMatcher(root)
+					if next, err := e.newWatcher(root, matcher); err == nil {
+						watcher.Close()
+						watcher = next
+					}
+					changed = []string{"."}
+				}
+				if len(changed) > 0 && ctx.Err() == nil {
+					onChange(changed)
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+// newWatcher watches the directories matcher doesn't skip, reporting
+// changes to files the patterns match and to .gitignore files
+func (e *Explorer) newWatcher(root string, matcher *ignore.Matcher) (watch.Watcher, error) {
+	return watch.New(root, watch.Options{
+		Skip: func(relPath string, isDir bool) bool {
+			if isDir {
+				return e.skipDir(matcher, relPath, filepath.Base(relPath))
+			}
+			if filepath.Base(relPath) == ".gitignore" {
+				return false
+			}
+			_, ok := e.patterns.Match(relPath)
+			return !ok
+		},
+	})
+}
+
+func (e *Explorer) newMatcher(root string) *ignore.Matcher {
+	if e.noIgnore {
+		return nil
+	}
+	return ignore.New(root)
+}
+
+// applyChanges updates the loaded files for events. It returns the paths
+// that changed, or reload when the tree has to be walked again.
+func (e *Explorer) applyChanges(root string, matcher *ignore.Matcher, events []watch.Event) (changed []string, reload bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	// Searches may hold the old slice, so it is copied rather than edited
+	files := make([]types.FileItem, len(e.allFiles))
+	copy(files, e.allFiles)
+
+	for _, event := range events {
+		if event.Op == watch.Rescan || filepath.Base(event.Path) == ".gitignore" ||
+			filepath.Base(event.OldPath) == ".gitignore" {
+			return nil, true
+		}
+
+		switch event.Op {
+		case watch.Remove:
+			files, changed = e.removeFiles(files, event.Path, changed)
+		case watch.Rename:
+			files, changed = e.removeFiles(files, event.OldPath, changed)
+			files, changed = e.upsertFile(files, root, matcher, event.Path, changed)
+		case watch.Create, watch.Write:
+			files, changed = e.upsertFile(files, root, matcher, event.Path, changed)
+		}
+	}
+
+	if len(changed) > 0 {
+		e.allFiles = files
+	}
+	return unique(changed), false
+}
+
+// ignoredFile reports whether relPath or a parent directory is ignored.
+// Personal Claude files are only ignored along with their directory.
+func ignoredFile(matcher *ignore.Matcher, relPath string) bool {
+	switch {
+	case matcher == nil:
+		return false
+	case isLocalClaudeFile(relPath):
+		return matcher.IsIgnored(filepath.Dir(relPath), true)
+	default:
+		return matcher.IsIgnored(relPath, false)
+	}
+}
+
+func unique(paths []string) []string {
+	seen := make(map[string]bool, len(paths))
+	out := paths[:0]
+	for _, path := range paths {
+		if !seen[path] {
+			seen[path] = true
+			out = append(out, path)
+		}
+	}
+	return out
+}
+
+// removeFiles drops the file at relPath, or everything below it when it was
+// a directory
+func (e *Explorer) removeFiles(files []types.FileItem, relPath string, changed []string) ([]types.FileItem, []string) {
+	prefix := relPath + string(filepath.Separator)
+	kept := files[:0]
+	for _, file := range files {
+		if file.Path == relPath || strings.HasPrefix(file.Path, prefix) {
+			e.index.Remove(file.Path)
+			changed = append(changed, file.Path)
+			continue
+		}
+		kept = append(kept, file)
+	}
+	return kept, changed
+}
+
+// upsertFile re-reads relPath, replacing the loaded copy in place or adding
+// it at the end. A file that no longer matches or is now ignored is dropped.
+func (e *Explorer) upsertFile(files []types.FileItem, root string, matcher *ignore.Matcher, relPath string, changed []string) ([]types.FileItem, []string) {
+	info, err := os.Stat(filepath.Join(root, relPath))
+	pattern, matched := e.patterns.Match(relPath)
+	if err != nil || info.IsDir() || !matched || ignoredFile(matcher, relPath) {
+		return e.removeFiles(files, relPath, changed)
+	}
+
+	file := readFile(root, relPath, pattern)
+	e.index.Add(file)
+	changed = append(changed, relPath)
+
+	for i := range files {
+		if files[i].Path == relPath {
+			files[i] = file
+			return files, changed
+		}
+	}
+	return append(files, file), changed
+}
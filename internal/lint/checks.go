@@ -0,0 +1,277 @@
+package lint
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"rules-explorer/internal/core/claudemd"
+	"rules-explorer/internal/core/glob"
+	"rules-explorer/internal/core/types"
+	"rules-explorer/internal/utils"
+	"rules-explorer/internal/validate"
+)
+
+func init() {
+	Register(&Check{
+		ID:          "mdc-missing-description",
+		Description: "Cursor rules need a description for the agent to decide when to apply them",
+		Severity:    SeverityWarning,
+		Run:         checkMissingDescription,
+	})
+	Register(&Check{
+		ID:          "mdc-empty-globs",
+		Description: "A rule with an empty globs list and no alwaysApply never attaches automatically",
+		Severity:    SeverityWarning,
+		Run:         checkEmptyGlobs,
+	})
+	Register(&Check{
+		ID:          "invalid-glob",
+		Description: "Globs in frontmatter must be valid patterns",
+		Severity:    SeverityError,
+		Run:         checkInvalidGlobs,
+	})
+	Register(&Check{
+		ID:          "malformed-frontmatter",
+		Description: "Frontmatter must be a closed block of key: value lines",
+		Severity:    SeverityError,
+		Run:         checkMalformedFrontmatter,
+	})
+	Register(&Check{
+		ID:          "oversized-file",
+		Description: "Large rule files use up context on every request they apply to",
+		Severity:    SeverityWarning,
+		Run:         checkOversized,
+	})
+	Register(&Check{
+		ID:          "todo",
+		Description: "TODO and FIXME markers left in rules are sent to the model as instructions",
+		Severity:    SeverityInfo,
+		Run:         checkTodos,
+	})
+	Register(&Check{
+		ID:          "broken-import",
+		Description: "@imports in CLAUDE.md files must point at existing files",
+		Severity:    SeverityError,
+		Run:         checkImports,
+	})
+	Register(&Check{
+		ID:          "invalid-config",
+		Description: "Claude settings and .mcp.json must match their schema: valid JSON, value types, permission rules and hook commands",
+		Severity:    SeverityError,
+		Run:         checkConfigErrors,
+	})
+	Register(&Check{
+		ID:          "unknown-config-key",
+		Description: "Keys Claude Code doesn't know in settings and .mcp.json are ignored, often because of a typo",
+		Severity:    SeverityWarning,
+		Run:         checkConfigWarnings,
+	})
+}
+
+func checkMissingDescription(ctx *Context, file types.FileItem) []Finding {
+	if file.Type() != types.CursorRule {
+		return nil
+	}
+	if file.Metadata == nil {
+		return []Finding{{Line: 1, Column: 1, Message: "no frontmatter; add a description"}}
+	}
+	if strings.TrimSpace(file.Metadata.Description) == "" && file.Metadata.Error == "" {
+		return []Finding{{Line: keyLine(file.Content, "description"), Column: 1, Message: "missing description"}}
+	}
+	return nil
+}
+
+func checkEmptyGlobs(ctx *Context, file types.FileItem) []Finding {
+	m := file.Metadata
+	if file.Type() != types.CursorRule || m == nil || m.AlwaysApply {
+		return nil
+	}
+	if _, ok := m.Fields["globs"]; ok && len(m.Globs) == 0 {
+		return []Finding{{
+			Line:    keyLine(file.Content, "globs"),
+			Column:  1,
+			Message: "globs is empty and alwaysApply is not set, so the rule only applies when requested",
+		}}
+	}
+	return nil
+}
+
+func checkInvalidGlobs(ctx *Context, file types.FileItem) []Finding {
+	if file.Metadata == nil {
+		return nil
+	}
+	findings := make([]Finding, 0)
+	for _, g := range file.Metadata.Globs {
+		if err := glob.Validate(g); err != nil {
+			findings = append(findings, Finding{
+				Line:    keyLine(file.Content, "globs"),
+				Column:  1,
+				Message: fmt.Sprintf("invalid glob %q: %v", g, err),
+			})
+		}
+	}
+	return findings
+}
+
+var frontmatterLine = regexp.MustCompile(`^line (\d+): `)
+
+func checkMalformedFrontmatter(ctx *Context, file types.FileItem) []Finding {
+	if file.Metadata == nil || file.Metadata.Error == "" {
+		return nil
+	}
+	line := 1
+	message := file.Metadata.Error
+	if m := frontmatterLine.FindStringSubmatch(message); m != nil {
+		line, _ = strconv.Atoi(m[1])
+		message = message[len(m[0]):]
+	}
+	return []Finding{{Line: line, Column: 1, Message: "malformed frontmatter: " + message}}
+}
+
+func checkOversized(ctx *Context, file types.FileItem) []Finding {
+	if len(file.Content) <= ctx.MaxSize {
+		return nil
+	}
+	return []Finding{{Message: fmt.Sprintf("file is %s, over the %s limit",
+		utils.FormatFileSize(len(file.Content)), utils.FormatFileSize(ctx.MaxSize))}}
+}
+
+// todoMarker is a marker as written in notes, "TODO:" or "FIXME(name):", so
+// rules that merely mention TODO comments aren't flagged
+var (
+	todoMarker = regexp.MustCompile(`\b(?:TODO|FIXME|XXX)(?:\([^)]*\))?:`)
+	inlineCode = regexp.MustCompile("`[^`]*`")
+)
+
+func checkTodos(ctx *Context, file types.FileItem) []Finding {
+	if !isMarkdown(file.Path) {
+		return nil
+	}
+
+	findings := make([]Finding, 0)
+	fence := ""
+	for i, line := range strings.Split(file.Content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fence = trimmed[:3]
+			continue
+		}
+
+		// Code spans are blanked out so columns stay the same
+		text := inlineCode.ReplaceAllStringFunc(line, func(code string) string {
+			return strings.Repeat(" ", len(code))
+		})
+		if loc := todoMarker.FindStringIndex(text); loc != nil {
+			findings = append(findings, Finding{
+				Line:    i + 1,
+				Column:  utf8.RuneCountInString(line[:loc[0]]) + 1,
+				Message: "leftover " + strings.TrimSpace(line[loc[0]:]),
+			})
+		}
+	}
+	return findings
+}
+
+// isMarkdown reports whether filePath is a markdown rule file: .md and .mdc
+// files, and extensionless ones like .windsurfrules
+func isMarkdown(filePath string) bool {
+	base := filepath.Base(filePath)
+	switch ext := filepath.Ext(base); ext {
+	case ".md", ".mdc", ".markdown", "", base:
+		return true
+	}
+	return false
+}
+
+func checkImports(ctx *Context, file types.FileItem) []Finding {
+	if file.Type() != types.ClaudeConfig {
+		return nil
+	}
+
+	path := file.Path
+	if ctx.Root != "" && !filepath.IsAbs(path) {
+		path = filepath.Join(ctx.Root, path)
+	}
+
+	findings := make([]Finding, 0)
+	for _, imp := range claudemd.ResolveImports(path, file.Content) {
+		problem := describeImport(imp)
+		if problem == "" {
+			continue
+		}
+		findings = append(findings, Finding{
+			Line:    imp.Line,
+			Column:  imp.Column,
+			Message: problem,
+		})
+	}
+	return findings
+}
+
+// describeImport explains why imp or something it imports can't be loaded.
+// Cycles aren't reported; the file is simply not loaded twice.
+func describeImport(imp *claudemd.Import) string {
+	if imp.Missing {
+		return fmt.Sprintf("%s: file not found", imp.Raw)
+	}
+	if parent, missing := firstMissing(imp); missing != nil {
+		return fmt.Sprintf("%s: %s imports %s, which was not found",
+			imp.Raw, filepath.Base(parent.Path), missing.Raw)
+	}
+	return ""
+}
+
+// firstMissing finds the first missing import below imp and the import
+// that references it
+func firstMissing(imp *claudemd.Import) (parent, missing *claudemd.Import) {
+	for _, child := range imp.Children {
+		if child.Missing {
+			return imp, child
+		}
+		if parent, missing := firstMissing(child); missing != nil {
+			return parent, missing
+		}
+	}
+	return nil, nil
+}
+
+// keyLine returns the line of key in the frontmatter, or 1
+func keyLine(content, key string) int {
+	for i, line := range strings.Split(content, "\n") {
+		if i > 0 && strings.TrimRight(line, "\r") == "---" {
+			break
+		}
+		if strings.HasPrefix(line, key+":") {
+			return i + 1
+		}
+	}
+	return 1
+}
+
+func checkConfigErrors(ctx *Context, file types.FileItem) []Finding {
+	return configFindings(file, validate.LevelError)
+}
+
+func checkConfigWarnings(ctx *Context, file types.FileItem) []Finding {
+	return configFindings(file, validate.LevelWarning)
+}
+
+func configFindings(file types.FileItem, level validate.Level) []Finding {
+	findings := make([]Finding, 0)
+	for _, problem := range validate.File(file.Path, file.Content) {
+		if problem.Level == level {
+			findings = append(findings, Finding{Line: problem.Line, Column: problem.Column, Message: problem.String()})
+		}
+	}
+	return findings
+}
@@ -0,0 +1,185 @@
+// Package lint checks discovered rule files for common mistakes. Checks are
+// registered with an ID and a default severity, and run over every file.
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"rules-explorer/internal/core/types"
+)
+
+type Severity int
+
+const (
+	SeverityInfo Severity = iota
+	SeverityWarning
+	SeverityError
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	default:
+		return "unknown"
+	}
+}
+
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+func ParseSeverity(value string) (Severity, error) {
+	switch strings.ToLower(value) {
+	case "info", "note":
+		return SeverityInfo, nil
+	case "warning", "warn":
+		return SeverityWarning, nil
+	case "error":
+		return SeverityError, nil
+	}
+	return 0, fmt.Errorf("unknown severity %q (want info, warning or error)", value)
+}
+
+// Finding is a problem reported by a check. Line and Column are 1-based; 0
+// means the finding applies to the whole file.
+type Finding struct {
+	Check    string   `json:"check"`
+	Severity Severity `json:"severity"`
+	Path     string   `json:"path"`
+	Line     int      `json:"line,omitempty"`
+	Column   int      `json:"column,omitempty"`
+	Message  string   `json:"message"`
+}
+
+// Check is a registered lint rule
+type Check struct {
+	ID          string
+	Description string
+	Severity    Severity
+	Run         func(ctx *Context, file types.FileItem) []Finding
+}
+
+// Context is shared by every check in a run
+type Context struct {
+	// Root is the directory file paths are relative to
+	Root string
+	// MaxSize is the size in bytes above which a file is oversized
+	MaxSize int
+}
+
+// DefaultMaxSize is the default limit for the oversized-file check
+const DefaultMaxSize = 32 * 1024
+
+var checks = make([]*Check, 0)
+
+// Register adds a check. IDs must be unique.
+func Register(check *Check) {
+	for _, existing := range checks {
+		if existing.ID == check.ID {
+			panic("lint: duplicate check " + check.ID)
+		}
+	}
+	checks = append(checks, check)
+}
+
+// Checks returns the registered checks in registration order
+func Checks() []*Check {
+	return checks
+}
+
+type Options struct {
+	Root    string
+	MaxSize int
+	// Disabled lists check IDs to skip
+	Disabled []string
+}
+
+// Run applies every enabled check to files. Findings are sorted by path,
+// then position.
+func Run(files []types.FileItem, opts Options) []Finding {
+	ctx := &Context{Root: opts.Root, MaxSize: opts.MaxSize}
+	if ctx.MaxSize <= 0 {
+		ctx.MaxSize = DefaultMaxSize
+	}
+
+	disabled := make(map[string]bool)
+	for _, id := range opts.Disabled {
+		disabled[id] = true
+	}
+
+	findings := make([]Finding, 0)
+	for _, file := range files {
+		for _, check := range checks {
+			if disabled[check.ID] {
+				continue
+			}
+			for _, finding := range check.Run(ctx, file) {
+				finding.Check = check.ID
+				finding.Severity = check.Severity
+				finding.Path = file.Path
+				findings = append(findings, finding)
+			}
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return findings
+}
+
+// Summary counts findings per severity
+type Summary struct {
+	Errors   int
+	Warnings int
+	Infos    int
+}
+
+func (s *Summary) Add(finding Finding) {
+	switch finding.Severity {
+	case SeverityError:
+		s.Errors++
+	case SeverityWarning:
+		s.Warnings++
+	default:
+		s.Infos++
+	}
+}
+
+// Worst returns the highest severity counted, and false when there is none
+func (s Summary) Worst() (Severity, bool) {
+	switch {
+	case s.Errors > 0:
+		return SeverityError, true
+	case s.Warnings > 0:
+		return SeverityWarning, true
+	case s.Infos > 0:
+		return SeverityInfo, true
+	}
+	return 0, false
+}
+
+// ByPath summarizes findings per file
+func ByPath(findings []Finding) map[string]Summary {
+	summaries := make(map[string]Summary)
+	for _, finding := range findings {
+		summary := summaries[finding.Path]
+		summary.Add(finding)
+		summaries[finding.Path] = summary
+	}
+	return summaries
+}
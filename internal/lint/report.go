@@ -0,0 +1,150 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+)
+
+// WriteText prints one finding per line as path:line:column: severity: message [check]
+func WriteText(w io.Writer, findings []Finding) error {
+	var summary Summary
+	for _, finding := range findings {
+		summary.Add(finding)
+		position := finding.Path
+		if finding.Line > 0 {
+			position = fmt.Sprintf("%s:%d:%d", finding.Path, finding.Line, max(finding.Column, 1))
+		}
+		if _, err := fmt.Fprintf(w, "%s: %s: %s [%s]\n", position, finding.Severity, finding.Message, finding.Check); err != nil {
+			return err
+		}
+	}
+
+	if len(findings) == 0 {
+		_, err := fmt.Fprintln(w, "No problems found")
+		return err
+	}
+	_, err := fmt.Fprintf(w, "\n%d errors, %d warnings, %d info\n", summary.Errors, summary.Warnings, summary.Infos)
+	return err
+}
+
+func WriteJSON(w io.Writer, findings []Finding) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(findings)
+}
+
+// SARIF 2.1.0, limited to what code scanning services read
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string       `json:"id"`
+	ShortDescription     sarifMessage `json:"shortDescription"`
+	DefaultConfiguration sarifConfig  `json:"defaultConfiguration"`
+}
+
+type sarifConfig struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifact `json:"artifactLocation"`
+	Region           *sarifRegion  `json:"region,omitempty"`
+}
+
+type sarifArtifact struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+func sarifLevel(severity Severity) string {
+	switch severity {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	}
+	return "note"
+}
+
+// WriteSARIF writes findings as a SARIF log, with every registered check
+// listed as a rule
+func WriteSARIF(w io.Writer, findings []Finding) error {
+	rules := make([]sarifRule, 0, len(checks))
+	ruleIndex := make(map[string]int, len(checks))
+	for i, check := range checks {
+		ruleIndex[check.ID] = i
+		rules = append(rules, sarifRule{
+			ID:                   check.ID,
+			ShortDescription:     sarifMessage{Text: check.Description},
+			DefaultConfiguration: sarifConfig{Level: sarifLevel(check.Severity)},
+		})
+	}
+
+	results := make([]sarifResult, 0, len(findings))
+	for _, finding := range findings {
+		location := sarifPhysicalLocation{
+			ArtifactLocation: sarifArtifact{URI: filepath.ToSlash(finding.Path)},
+		}
+		if finding.Line > 0 {
+			location.Region = &sarifRegion{StartLine: finding.Line, StartColumn: finding.Column}
+		}
+		results = append(results, sarifResult{
+			RuleID:    finding.Check,
+			RuleIndex: ruleIndex[finding.Check],
+			Level:     sarifLevel(finding.Severity),
+			Message:   sarifMessage{Text: finding.Message},
+			Locations: []sarifLocation{{PhysicalLocation: location}},
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool:    sarifTool{Driver: sarifDriver{Name: "rules-explorer", Rules: rules}},
+			Results: results,
+		}},
+	})
+}
@@ -0,0 +1,173 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"rules-explorer/internal/analysis"
+	"rules-explorer/internal/core/types"
+)
+
+// conflictContext is how many lines are shown around each statement
+const conflictContext = 3
+
+// ConflictsComponent lists contradicting statements as a modal, with the two
+// sides of the selected conflict next to each other
+type ConflictsComponent struct {
+	list         *tview.List
+	left         *tview.TextView
+	right        *tview.TextView
+	frame        *tview.Flex
+	conflicts    []analysis.Conflict
+	theme        types.Theme
+	eventHandler types.EventHandler
+	onOpen       func(statement analysis.Statement)
+	onClose      func()
+}
+
+func NewConflictsComponent(th types.Theme) *ConflictsComponent {
+	c := &ConflictsComponent{
+		list:  tview.NewList(),
+		left:  tview.NewTextView(),
+		right: tview.NewTextView(),
+		theme: th,
+	}
+	
+	c.setupList()
+	return c
+}
+
+func (c *ConflictsComponent) setupList() {
+	colors := c.theme.GetColors()
+	
+	c.list.
+		ShowSecondaryText(true).
+		SetHighlightFullLine(true).
+		SetMainTextColor(colors.Text).
+		SetSecondaryTextColor(colors.Secondary).
+		SetSelectedBackgroundColor(tcell.ColorDefault).
+		SetSelectedTextColor(tcell.ColorYellow)
+	
+	c.list.SetBorder(true).
+		SetTitle("[yellow]⚡ Conflicts (Enter: open left side, Esc: close)[-]").
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(colors.BorderFocus).
+		SetBackgroundColor(tcell.ColorDefault)
+	
+	for _, side := range []*tview.TextView{c.left, c.right} {
+		side.
+			SetDynamicColors(true).
+			SetWrap(true).
+			SetTextStyle(tcell.StyleDefault.Background(tcell.ColorDefault).Foreground(colors.Text))
+		side.SetBorder(true).
+			SetTitleAlign(tview.AlignLeft).
+			SetBorderColor(colors.Border).
+			SetBackgroundColor(tcell.ColorDefault)
+	}
+	
+	c.list.SetChangedFunc(func(index int, mainText, secondaryText string, shortcut rune) {
+		c.showConflict(index)
+	})
+	c.list.SetSelectedFunc(func(index int, mainText, secondaryText string, shortcut rune) {
+		if index < len(c.conflicts) && c.onOpen != nil {
+			c.onOpen(c.conflicts[index].Require)
+		}
+	})
+	c.list.SetDoneFunc(func() {
+		if c.onClose != nil {
+			c.onClose()
+		}
+	})
+	
+	sides := tview.NewFlex().
+		AddItem(c.left, 0, 1, false).
+		AddItem(c.right, 0, 1, false)
+	
+	content := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(c.list, 0, 1, true).
+		AddItem(sides, 0, 2, false)
+	
+	c.frame = tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(content, 0, 6, true).
+			AddItem(nil, 0, 1, false), 0, 8, true).
+		AddItem(nil, 0, 1, false)
+}
+
+// Show lists conflicts; onOpen is called with the statement to preview and
+// onClose when the user leaves the view
+func (c *ConflictsComponent) Show(conflicts []analysis.Conflict, onOpen func(statement analysis.Statement), onClose func()) {
+	c.conflicts = conflicts
+	c.onOpen = onOpen
+	c.onClose = onClose
+	
+	c.list.Clear()
+	for _, conflict := range conflicts {
+		secondary := fmt.Sprintf("  %s ↔ %s", conflict.Require.Location(), conflict.Forbid.Location())
+		c.list.AddItem(tview.Escape(conflict.Topic()), tview.Escape(secondary), 0, nil)
+	}
+	if len(conflicts) == 0 {
+		c.list.AddItem("[gray](no conflicts)[-]", "", 0, nil)
+	}
+	
+	c.list.SetCurrentItem(0)
+	c.showConflict(0)
+}
+
+func (c *ConflictsComponent) showConflict(index int) {
+	if index >= len(c.conflicts) {
+		for _, side := range []*tview.TextView{c.left, c.right} {
+			side.SetTitle("")
+			side.SetText("")
+		}
+		return
+	}
+	
+	conflict := c.conflicts[index]
+	c.showStatement(c.left, conflict.Require, "[green]")
+	c.showStatement(c.right, conflict.Forbid, "[red]")
+}
+
+// showStatement shows the lines around a statement with its first line marked
+func (c *ConflictsComponent) showStatement(view *tview.TextView, statement analysis.Statement, color string) {
+	view.SetTitle(color + tview.Escape(statement.Location()) + "[-]")
+	
+	lines := strings.Split(statement.File.Content, "\n")
+	first := max(statement.Line-conflictContext, 1)
+	last := min(statement.Line+conflictContext, len(lines))
+	
+	var b strings.Builder
+	for n := first; n <= last; n++ {
+		text := tview.Escape(lines[n-1])
+		if n == statement.Line {
+			fmt.Fprintf(&b, "%s%4d ▶ %s[-]\n", color, n, text)
+		} else {
+			fmt.Fprintf(&b, "[gray]%4d[-]   %s\n", n, text)
+		}
+	}
+	view.SetText(b.String()).ScrollToBeginning()
+}
+
+func (c *ConflictsComponent) GetPrimitive() tview.Primitive {
+	return c.frame
+}
+
+func (c *ConflictsComponent) GetList() tview.Primitive {
+	return c.list
+}
+
+func (c *ConflictsComponent) SetEventHandler(handler types.EventHandler) {
+	c.eventHandler = handler
+}
+
+func (c *ConflictsComponent) Focus() {}
+
+func (c *ConflictsComponent) Blur() {}
+
+func (c *ConflictsComponent) Update(data interface{}) {
+	// Conflicts component is driven through Show
+}
@@ -2,8 +2,13 @@ package components
 
 import (
 	"fmt"
+	"strings"
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
+	"rules-explorer/internal/core/claudemd"
+	"rules-explorer/internal/core/search"
+	"rules-explorer/internal/core/settings"
+	"rules-explorer/internal/core/tokens"
 	"rules-explorer/internal/core/types"
 	"rules-explorer/internal/ui/theme"
 	"rules-explorer/internal/utils"
@@ -13,12 +18,14 @@ type DetailsComponent struct {
 	textView     *tview.TextView
 	theme        types.Theme
 	eventHandler types.EventHandler
+	budget       tokens.Budget
 }
 
 func NewDetailsComponent(th types.Theme) *DetailsComponent {
 	d := &DetailsComponent{
 		textView: tview.NewTextView(),
 		theme:    th,
+		budget:   tokens.DefaultBudget,
 	}
 	
 	d.setupTextView()
@@ -65,6 +72,11 @@ func (d *DetailsComponent) Blur() {
 	d.textView.SetBorderColor(colors.Border)
 }
 
+// SetTokenBudget sets the per-file token count above which a warning is shown
+func (d *DetailsComponent) SetTokenBudget(budget tokens.Budget) {
+	d.budget = budget
+}
+
 func (d *DetailsComponent) Update(data interface{}) {
 	if file, ok := data.(types.FileItem); ok {
 		d.updateFileDetails(file)
@@ -76,7 +88,7 @@ func (d *DetailsComponent) updateFileDetails(file types.FileItem) {
 	lineCount := utils.CountLines(file.Content)
 	
 	sizeStr := utils.FormatFileSize(size)
-	fileType := theme.DetermineFileType(file.Path)
+	fileType := theme.DetermineItemType(file)
 	icons := d.theme.GetIcons()
 	icon := theme.GetFileTypeIcon(fileType, icons)
 	
@@ -86,19 +98,177 @@ func (d *DetailsComponent) updateFileDetails(file types.FileItem) {
 [yellow]Type:[-] %s
 [yellow]Size:[-] %s
 [yellow]Lines:[-] %d
-
+[yellow]Tokens:[-] %s
+%s%s%s
 [yellow]Content Preview:[-]
 [gray]%s[-]`,
 		icon, utils.GetBaseName(file.Path),
 		file.Path,
-		fileType.String(),
+		theme.FileTypeName(file),
 		sizeStr,
 		lineCount,
+		d.formatTokens(file),
+		d.formatMetadata(file),
+		d.formatImports(file),
+		d.formatSettings(file),
 		utils.GetContentPreview(file.Content, 10, 100))
 	
 	d.textView.SetText(details)
 }
 
+func (d *DetailsComponent) formatTokens(file types.FileItem) string {
+	count := tokens.Count(file.Content)
+	if d.budget.FileExceeded(count) {
+		return fmt.Sprintf("[red]~%s ⚠ over the %s budget[-]", tokens.Format(count), tokens.Format(d.budget.File))
+	}
+	return "~" + tokens.Format(count)
+}
+
+func (d *DetailsComponent) formatImports(file types.FileItem) string {
+	// Imports are a CLAUDE.md feature; unlabeled items are files opened
+	// through an import
+	if file.Type() != types.ClaudeConfig && file.Label != "" {
+		return ""
+	}
+	
+	imports := claudemd.ResolveImports(file.Path, file.Content)
+	if len(imports) == 0 {
+		return ""
+	}
+	
+	return "\n[yellow]Imports:[-] [gray](i to navigate)[-]\n" + FormatImportTree(imports)
+}
+
+func (d *DetailsComponent) formatSettings(file types.FileItem) string {
+	scope, ok := settings.ScopeOf(file.Path)
+	if !ok {
+		return ""
+	}
+	
+	parsed, err := settings.Parse(file.Content)
+	if err != nil {
+		return fmt.Sprintf("\n[red]Invalid settings: %s[-]\n", tview.Escape(err.Error()))
+	}
+	
+	return fmt.Sprintf("\n[yellow]Settings:[-] %s [gray](s for the effective view)[-]\n", scopeTag(scope)) + FormatSettings(parsed)
+}
+
+// ShowSettings lists the settings files merged into the effective view
+func (d *DetailsComponent) ShowSettings(files []settings.File) {
+	var b strings.Builder
+	b.WriteString("[white]Claude Code settings[-]\n\n")
+	
+	if len(files) == 0 {
+		b.WriteString("[yellow]No settings files found[-]")
+		d.textView.SetText(b.String())
+		return
+	}
+	
+	for i, file := range files {
+		status := "[gray]" + tview.Escape(describeSettings(file.Settings)) + "[-]"
+		if file.Err != nil {
+			status = "[red]not parsed[-]"
+		}
+		fmt.Fprintf(&b, "%d. %s %s %s\n", i+1, tview.Escape(file.DisplayPath), scopeTag(file.Scope), status)
+	}
+	
+	d.textView.SetText(b.String())
+	d.textView.ScrollToBeginning()
+}
+
+// describeSettings counts what a settings file sets
+func describeSettings(s *settings.Settings) string {
+	permissions := len(s.Permissions.Allow) + len(s.Permissions.Ask) + len(s.Permissions.Deny)
+	return fmt.Sprintf("(%d rules, %d env, %d hooks)", permissions, len(s.Env), s.HookCount())
+}
+
+func (d *DetailsComponent) formatMetadata(file types.FileItem) string {
+	metadata := file.Metadata
+	if metadata == nil {
+		return ""
+	}
+	
+	var b strings.Builder
+	b.WriteString("\n[yellow]Frontmatter:[-]\n")
+	
+	if metadata.Error != "" {
+		fmt.Fprintf(&b, "[red]Malformed: %s[-]\n", tview.Escape(metadata.Error))
+	}
+	
+	switch file.Type() {
+	case types.ClaudeCommand, types.ClaudeAgent, types.ClaudeSkill:
+		if metadata.Description != "" {
+			fmt.Fprintf(&b, "[yellow]Description:[-] %s\n", tview.Escape(metadata.Description))
+		}
+		if len(metadata.AllowedTools) > 0 {
+			fmt.Fprintf(&b, "[yellow]Allowed Tools:[-] %s\n", tview.Escape(strings.Join(metadata.AllowedTools, ", ")))
+		}
+		if metadata.Model != "" {
+			fmt.Fprintf(&b, "[yellow]Model:[-] %s\n", tview.Escape(metadata.Model))
+		}
+		if metadata.ArgumentHint != "" {
+			fmt.Fprintf(&b, "[yellow]Argument Hint:[-] %s\n", tview.Escape(metadata.ArgumentHint))
+		}
+	default:
+		fmt.Fprintf(&b, "[yellow]Mode:[-] %s\n", metadata.Mode().String())
+		if metadata.Description != "" {
+			fmt.Fprintf(&b, "[yellow]Description:[-] %s\n", tview.Escape(metadata.Description))
+		}
+		if len(metadata.Globs) > 0 {
+			fmt.Fprintf(&b, "[yellow]Globs:[-] %s\n", tview.Escape(strings.Join(metadata.Globs, ", ")))
+		}
+		fmt.Fprintf(&b, "[yellow]Always Apply:[-] %t\n", metadata.AlwaysApply)
+	}
+	
+	for _, key := range metadata.Keys {
+		switch key {
+		case "description", "globs", "alwaysApply":
+			continue
+		case "allowed-tools", "tools", "model", "argument-hint":
+			switch file.Type() {
+			case types.ClaudeCommand, types.ClaudeAgent, types.ClaudeSkill:
+				continue
+			}
+		}
+		fmt.Fprintf(&b, "[yellow]%s:[-] %s\n", tview.Escape(key), tview.Escape(metadata.Fields[key]))
+	}
+	
+	return b.String()
+}
+
+// ShowHierarchy lists the effective CLAUDE.md chain for a directory
+func (d *DetailsComponent) ShowHierarchy(target string, entries []claudemd.Entry) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "[white]CLAUDE.md chain for[-] %s\n\n", tview.Escape(target))
+	
+	if len(entries) == 0 {
+		b.WriteString("[yellow]No CLAUDE.md files in effect[-]")
+		d.textView.SetText(b.String())
+		return
+	}
+	
+	for i, entry := range entries {
+		color := "[green]"
+		if entry.OnDemand() {
+			color = "[gray]"
+		} else if entry.Local {
+			color = "[aqua]"
+		}
+		fmt.Fprintf(&b, "%s%d. %s[-] [gray](%s, %s)[-]\n",
+			color, i+1, tview.Escape(entry.DisplayPath), entry.Scope.String(), utils.FormatFileSize(len(entry.Content)))
+	}
+	
+	d.textView.SetText(b.String())
+	d.textView.ScrollToBeginning()
+}
+
+// ShowLineMatch shows a grep result with its surrounding lines
+func (d *DetailsComponent) ShowLineMatch(match search.LineMatch) {
+	d.textView.SetText(fmt.Sprintf("[white]%s[-] [gray]line %d, column %d[-]\n\n%s",
+		tview.Escape(match.File.Path), match.Line, match.Column, FormatContext(match)))
+	d.textView.ScrollToBeginning()
+}
+
 func (d *DetailsComponent) SetNoFileSelected() {
 	d.textView.SetText("[yellow]No files selected[-]")
 }
\ No newline at end of file
@@ -0,0 +1,164 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"rules-explorer/internal/analysis"
+	"rules-explorer/internal/core/types"
+)
+
+// DupesComponent lists clusters of duplicated paragraphs as a modal tree,
+// with the text of the selected copy beside it
+type DupesComponent struct {
+	tree         *tview.TreeView
+	text         *tview.TextView
+	frame        *tview.Flex
+	theme        types.Theme
+	eventHandler types.EventHandler
+	onOpen       func(paragraph analysis.Paragraph)
+	onClose      func()
+}
+
+func NewDupesComponent(th types.Theme) *DupesComponent {
+	d := &DupesComponent{
+		tree:  tview.NewTreeView(),
+		text:  tview.NewTextView(),
+		theme: th,
+	}
+	
+	d.setupTree()
+	return d
+}
+
+func (d *DupesComponent) setupTree() {
+	colors := d.theme.GetColors()
+	
+	d.tree.
+		SetGraphics(true).
+		SetGraphicsColor(colors.Secondary)
+	
+	d.tree.SetBorder(true).
+		SetTitle("[yellow]⧉ Duplicates (Enter: open copy, Esc: close)[-]").
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(colors.BorderFocus).
+		SetBackgroundColor(tcell.ColorDefault)
+	
+	d.text.
+		SetDynamicColors(true).
+		SetWordWrap(true).
+		SetTextStyle(tcell.StyleDefault.Background(tcell.ColorDefault).Foreground(colors.Text))
+	d.text.SetBorder(true).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(colors.Border).
+		SetBackgroundColor(tcell.ColorDefault)
+	
+	d.tree.SetChangedFunc(d.onNodeChanged)
+	d.tree.SetSelectedFunc(d.onNodeSelected)
+	d.tree.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEscape && d.onClose != nil {
+			d.onClose()
+		}
+	})
+	
+	content := tview.NewFlex().
+		AddItem(d.tree, 0, 1, true).
+		AddItem(d.text, 0, 1, false)
+	
+	d.frame = tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(content, 0, 6, true).
+			AddItem(nil, 0, 1, false), 0, 8, true).
+		AddItem(nil, 0, 1, false)
+}
+
+// Show lists clusters; onOpen is called when a copy is chosen and onClose
+// when the user leaves the view
+func (d *DupesComponent) Show(clusters []analysis.Cluster, onOpen func(paragraph analysis.Paragraph), onClose func()) {
+	d.onOpen = onOpen
+	d.onClose = onClose
+	
+	root := tview.NewTreeNode(fmt.Sprintf("%d duplicated paragraphs", len(clusters))).
+		SetColor(tcell.ColorYellow).
+		SetSelectable(false)
+	for _, cluster := range clusters {
+		color := tcell.ColorYellow
+		if cluster.Exact {
+			color = tcell.ColorRed
+		}
+		node := tview.NewTreeNode(cluster.Summary()).
+			SetColor(color).
+			SetReference(cluster)
+		for _, paragraph := range cluster.Paragraphs {
+			node.AddChild(tview.NewTreeNode(paragraph.Location()).SetReference(paragraph))
+		}
+		root.AddChild(node)
+	}
+	if len(clusters) == 0 {
+		root.AddChild(tview.NewTreeNode("(no duplicates)").SetColor(tcell.ColorGray).SetSelectable(false))
+	}
+	
+	d.tree.SetRoot(root)
+	if children := root.GetChildren(); len(children) > 0 {
+		d.tree.SetCurrentNode(children[0])
+		d.onNodeChanged(children[0])
+	} else {
+		d.tree.SetCurrentNode(root)
+		d.text.SetTitle("")
+		d.text.SetText("")
+	}
+}
+
+func (d *DupesComponent) onNodeChanged(node *tview.TreeNode) {
+	switch ref := node.GetReference().(type) {
+	case analysis.Cluster:
+		// Show every copy, so drift between them is visible
+		var b strings.Builder
+		for i, paragraph := range ref.Paragraphs {
+			if i > 0 {
+				b.WriteString("\n")
+			}
+			fmt.Fprintf(&b, "[aqua]%s[-]\n%s\n", tview.Escape(paragraph.Location()), tview.Escape(paragraph.Text))
+		}
+		d.text.SetTitle("[white]" + ref.Summary() + "[-]")
+		d.text.SetText(b.String()).ScrollToBeginning()
+	case analysis.Paragraph:
+		d.text.SetTitle("[aqua]" + tview.Escape(ref.Location()) + "[-]")
+		d.text.SetText(tview.Escape(ref.Text)).ScrollToBeginning()
+	}
+}
+
+func (d *DupesComponent) onNodeSelected(node *tview.TreeNode) {
+	switch ref := node.GetReference().(type) {
+	case analysis.Cluster:
+		node.SetExpanded(!node.IsExpanded())
+	case analysis.Paragraph:
+		if d.onOpen != nil {
+			d.onOpen(ref)
+		}
+	}
+}
+
+func (d *DupesComponent) GetPrimitive() tview.Primitive {
+	return d.frame
+}
+
+func (d *DupesComponent) GetTree() tview.Primitive {
+	return d.tree
+}
+
+func (d *DupesComponent) SetEventHandler(handler types.EventHandler) {
+	d.eventHandler = handler
+}
+
+func (d *DupesComponent) Focus() {}
+
+func (d *DupesComponent) Blur() {}
+
+func (d *DupesComponent) Update(data interface{}) {
+	// Dupes component is driven through Show
+}
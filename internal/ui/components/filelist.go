@@ -2,9 +2,11 @@ package components
 
 import (
 	"fmt"
+	"sort"
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 	"rules-explorer/internal/core/types"
+	"rules-explorer/internal/lint"
 	"rules-explorer/internal/ui/theme"
 	"rules-explorer/internal/utils"
 )
@@ -14,6 +16,13 @@ type FileListComponent struct {
 	theme        types.Theme
 	eventHandler types.EventHandler
 	files        []types.FileItem
+	badges       map[string]lint.Summary
+	// rows maps list items to indexes in files; section headers are -1
+	rows []int
+	// grouped sorts files into a section per type
+	grouped bool
+	// building suppresses selection events while the list is rebuilt
+	building bool
 }
 
 func NewFileListComponent(th types.Theme) *FileListComponent {
@@ -21,6 +30,7 @@ func NewFileListComponent(th types.Theme) *FileListComponent {
 		list:  tview.NewList(),
 		theme: th,
 		files: make([]types.FileItem, 0),
+		grouped: true,
 	}
 	
 	f.setupList()
@@ -66,8 +76,9 @@ func (f *FileListComponent) setupList() {
 		SetBackgroundColor(tcell.ColorDefault)
 }
 
-func (f *FileListComponent) onFileSelected(index int, mainText string, secondaryText string, shortcut rune) {
-	if f.eventHandler != nil && index < len(f.files) {
+func (f *FileListComponent) onFileSelected(row int, mainText string, secondaryText string, shortcut rune) {
+	index := f.fileIndex(row)
+	if f.eventHandler != nil && index >= 0 {
 		event := types.Event{
 			Type: types.EventFileSelected,
 			Data: types.FileEvent{
@@ -79,8 +90,18 @@ func (f *FileListComponent) onFileSelected(index int, mainText string, secondary
 	}
 }
 
-func (f *FileListComponent) onFileChanged(index int, mainText string, secondaryText string, shortcut rune) {
-	if f.eventHandler != nil && index >= 0 && index < len(f.files) {
+func (f *FileListComponent) onFileChanged(row int, mainText string, secondaryText string, shortcut rune) {
+	if f.building {
+		return
+	}
+	if row >= 0 && row < len(f.rows) && f.rows[row] < 0 {
+		// Section headers can't be selected; move past them
+		f.list.SetCurrentItem(f.nextFileRow(row, 1))
+		return
+	}
+	
+	index := f.fileIndex(row)
+	if f.eventHandler != nil && index >= 0 {
 		event := types.Event{
 			Type: types.EventFileChanged,
 			Data: types.FileEvent{
@@ -131,27 +152,202 @@ func (f *FileListComponent) Update(data interface{}) {
 
 func (f *FileListComponent) updateFiles(files []types.FileItem) {
 	f.files = files
+	if f.grouped {
+		f.rebuild()
+		f.list.SetCurrentItem(f.nextFileRow(0, 1))
+		return
+	}
+	
 	f.list.Clear()
+	f.rows = f.rows[:0]
+	f.addItems(files)
 	
+	if len(files) > 0 {
+		f.list.SetCurrentItem(0)
+	}
+}
+
+// SetGrouped turns sections per file type on or off from the next update
+func (f *FileListComponent) SetGrouped(grouped bool) {
+	f.grouped = grouped
+}
+
+// Append adds files to the end of the list, leaving the selection alone
+func (f *FileListComponent) Append(files []types.FileItem) {
+	first := len(f.files)
+	f.files = append(f.files[:len(f.files):len(f.files)], files...)
+	if !f.grouped {
+		f.addItems(files)
+		return
+	}
+	
+	// New files land in their sections; keep the selected file selected
+	selected := f.SelectedIndex()
+	f.rebuild()
+	if selected < 0 {
+		if first < len(f.files) {
+			f.list.SetCurrentItem(f.nextFileRow(0, 1))
+		}
+		return
+	}
+	f.building = true
+	f.list.SetCurrentItem(f.rowOf(selected))
+	f.building = false
+}
+
+func (f *FileListComponent) addItems(files []types.FileItem) {
+	first := len(f.files) - len(files)
+	for i, file := range files {
+		f.addFile(first+i, file)
+	}
+}
+
+func (f *FileListComponent) addFile(index int, file types.FileItem) {
 	icons := f.theme.GetIcons()
+	fileTypeEnum := theme.DetermineItemType(file)
+	icon := theme.GetFileTypeIconPlain(fileTypeEnum, icons)
+	shortPath := utils.GetShortPath(file.Path, 80)
+	
+	f.rows = append(f.rows, index)
+	f.list.AddItem(
+		fmt.Sprintf("%s %s", icon, shortPath),
+		f.secondaryText(file), // Remove color tags completely
+		0,
+		nil,
+	)
+}
+
+// rebuild lists the files in sections per type, in the order of
+// types.KnownFileTypes with custom types last. Selection events are held back;
+// the caller selects a row.
+func (f *FileListComponent) rebuild() {
+	f.building = true
+	defer func() { f.building = false }()
+	
+	f.list.Clear()
+	f.rows = f.rows[:0]
 	
-	for _, file := range files {
-		fileTypeEnum := theme.DetermineFileType(file.Path)
-		icon := theme.GetFileTypeIconPlain(fileTypeEnum, icons)
-		shortPath := utils.GetShortPath(file.Path, 80)
-		fileType := fileTypeEnum.String()
+	order := make([]int, len(f.files))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := f.files[order[i]], f.files[order[j]]
+		if rankA, rankB := typeRank(a), typeRank(b); rankA != rankB {
+			return rankA < rankB
+		}
+		return theme.FileTypeName(a) < theme.FileTypeName(b)
+	})
+	
+	for start := 0; start < len(order); {
+		name := theme.FileTypeName(f.files[order[start]])
+		end := start
+		for end < len(order) && theme.FileTypeName(f.files[order[end]]) == name {
+			end++
+		}
 		
-		f.list.AddItem(
-			fmt.Sprintf("%s %s", icon, shortPath),
-			fileType, // Remove color tags completely
-			0,
-			nil,
-		)
+		count := fmt.Sprintf("%d files", end-start)
+		if end-start == 1 {
+			count = "1 file"
+		}
+		f.rows = append(f.rows, -1)
+		f.list.AddItem("── "+name+" ──", "   "+count, 0, nil)
+		for _, index := range order[start:end] {
+			f.addFile(index, f.files[index])
+		}
+		start = end
+	}
+}
+
+func typeRank(file types.FileItem) int {
+	fileType := theme.DetermineItemType(file)
+	for rank, known := range types.KnownFileTypes {
+		if known == fileType {
+			return rank
+		}
+	}
+	return len(types.KnownFileTypes)
+}
+
+// fileIndex maps a list row to an index in files, or -1 for headers
+func (f *FileListComponent) fileIndex(row int) int {
+	if row < 0 || row >= len(f.rows) {
+		return -1
+	}
+	return f.rows[row]
+}
+
+func (f *FileListComponent) rowOf(index int) int {
+	for row, i := range f.rows {
+		if i == index {
+			return row
+		}
+	}
+	return 0
+}
+
+// nextFileRow finds the first file row from row on in direction, turning
+// around at the ends of the list
+func (f *FileListComponent) nextFileRow(row, direction int) int {
+	for r := row; r >= 0 && r < len(f.rows); r += direction {
+		if f.rows[r] >= 0 {
+			return r
+		}
+	}
+	for r := row; r >= 0 && r < len(f.rows); r -= direction {
+		if f.rows[r] >= 0 {
+			return r
+		}
+	}
+	return row
+}
+
+// SelectedIndex returns the index in files of the selected file, or -1
+func (f *FileListComponent) SelectedIndex() int {
+	return f.fileIndex(f.list.GetCurrentItem())
+}
+
+// SetBadges sets the lint summary shown next to each file, keyed by path
+func (f *FileListComponent) SetBadges(badges map[string]lint.Summary) {
+	f.badges = badges
+	for row, index := range f.rows {
+		if index < 0 {
+			continue
+		}
+		main, _ := f.list.GetItemText(row)
+		f.list.SetItemText(row, main, f.secondaryText(f.files[index]))
+	}
+}
+
+func (f *FileListComponent) secondaryText(file types.FileItem) string {
+	fileType := theme.FileTypeName(file)
+	summary, ok := f.badges[file.Path]
+	if !ok {
+		return fileType
 	}
 	
-	if len(files) > 0 {
-		f.list.SetCurrentItem(0)
+	badge := ""
+	if summary.Errors > 0 {
+		badge += fmt.Sprintf(" ✖%d", summary.Errors)
 	}
+	if summary.Warnings > 0 {
+		badge += fmt.Sprintf(" ⚠%d", summary.Warnings)
+	}
+	if summary.Infos > 0 {
+		badge += fmt.Sprintf(" ℹ%d", summary.Infos)
+	}
+	return fileType + badge
+}
+
+// SelectPath selects the file with the given path, if it is listed
+func (f *FileListComponent) SelectPath(path string) (int, bool) {
+	for i, file := range f.files {
+		if file.Path == path {
+			f.list.SetCurrentItem(f.rowOf(i))
+			return i, true
+		}
+	}
+	return 0, false
 }
 
 func (f *FileListComponent) GetCurrentItem() int {
@@ -167,15 +363,19 @@ func (f *FileListComponent) GetItemCount() int {
 }
 
 func (f *FileListComponent) NavigateUp() {
-	current := f.list.GetCurrentItem()
-	if current > 0 {
-		f.list.SetCurrentItem(current - 1)
+	for row := f.list.GetCurrentItem() - 1; row >= 0; row-- {
+		if f.rows[row] >= 0 {
+			f.list.SetCurrentItem(row)
+			return
+		}
 	}
 }
 
 func (f *FileListComponent) NavigateDown() {
-	current := f.list.GetCurrentItem()
-	if current < f.list.GetItemCount()-1 {
-		f.list.SetCurrentItem(current + 1)
+	for row := f.list.GetCurrentItem() + 1; row < len(f.rows); row++ {
+		if f.rows[row] >= 0 {
+			f.list.SetCurrentItem(row)
+			return
+		}
 	}
 }
\ No newline at end of file
@@ -48,14 +48,32 @@ func (h *HelpComponent) setupTextView() {
 [white]Shift+Tab/h[-] - Previous pane
 [white]Ctrl+P/N[-]  - Navigate files
 [white]Enter[-]     - Select file
+[white]Ctrl+R[-]    - Toggle regex search
+[white]Ctrl+G[-]    - Toggle matching lines view
+[white]n/N[-]       - Next/previous match
 [white]e[-]         - Edit file
+[white]a[-]         - Rules applying to a path
+[white]c[-]         - CLAUDE.md hierarchy
+[white]i[-]         - Navigate @imports
+[white]d[-]         - Duplicated paragraphs
+[white]x[-]         - Conflicting instructions
+[white]s[-]         - Effective Claude settings
 [white]q/Esc[-]     - Exit
+[white]Esc[-]       - Cancel scan (while loading)
 [white]Ctrl+C[-]    - Quit
 
 [yellow]File Types:[-]
 [red]` + icons.CursorRule + `[-] Cursor Rules (.mdc)
 [green]` + icons.ClaudeConfig + `[-] Claude Config (CLAUDE.md)
-[blue]` + icons.ConfigFile + `[-]  Config (.claude/*)`
+[blue]` + icons.ConfigFile + `[-]  Config (.claude/*, .mcp.json)
+[yellow]` + icons.ClaudeCommand + `[-] Commands (.claude/commands/**/*.md)
+[lime]` + icons.ClaudeAgent + `[-] Subagents (.claude/agents/*.md)
+[pink]` + icons.ClaudeSkill + `[-] Skills (.claude/skills/*/SKILL.md)
+[orange]` + icons.AgentsConfig + `[-] Agents (AGENTS.md)
+[purple]` + icons.GeminiConfig + `[-] Gemini (GEMINI.md)
+[teal]` + icons.CopilotConfig + `[-] Copilot (.github/*instructions.md)
+[aqua]` + icons.WindsurfRule + `[-] Windsurf (.windsurfrules)
+[fuchsia]` + icons.ClineRule + `[-] Cline (.clinerules)`
 	
 	h.textView.SetText(helpText)
 }
@@ -0,0 +1,194 @@
+package components
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"rules-explorer/internal/core/claudemd"
+	"rules-explorer/internal/core/types"
+)
+
+// ImportsComponent shows the @import dependency tree of a memory file as a
+// navigable modal
+type ImportsComponent struct {
+	tree         *tview.TreeView
+	frame        *tview.Flex
+	theme        types.Theme
+	eventHandler types.EventHandler
+	onClose      func()
+}
+
+func NewImportsComponent(th types.Theme) *ImportsComponent {
+	i := &ImportsComponent{
+		tree:  tview.NewTreeView(),
+		theme: th,
+	}
+	
+	i.setupTree()
+	return i
+}
+
+func (i *ImportsComponent) setupTree() {
+	colors := i.theme.GetColors()
+	
+	i.tree.
+		SetGraphics(true).
+		SetGraphicsColor(colors.Secondary)
+	
+	i.tree.SetBorder(true).
+		SetTitle("[yellow]🔗 Imports (Enter: open, Esc: close)[-]").
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(colors.BorderFocus).
+		SetBackgroundColor(tcell.ColorDefault)
+	
+	i.tree.SetSelectedFunc(i.onNodeSelected)
+	i.tree.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEscape && i.onClose != nil {
+			i.onClose()
+		}
+	})
+	
+	i.frame = tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(i.tree, 0, 3, true).
+			AddItem(nil, 0, 1, false), 0, 3, true).
+		AddItem(nil, 0, 1, false)
+}
+
+// Show builds the tree for file; onClose is called when the user leaves it
+func (i *ImportsComponent) Show(file types.FileItem, imports []*claudemd.Import, onClose func()) {
+	i.onClose = onClose
+	
+	root := tview.NewTreeNode(file.Path).
+		SetColor(tcell.ColorYellow).
+		SetReference(file)
+	addImportNodes(root, imports)
+	if len(imports) == 0 {
+		root.AddChild(tview.NewTreeNode("(no imports)").SetColor(tcell.ColorGray).SetSelectable(false))
+	}
+	
+	i.tree.SetRoot(root).SetCurrentNode(root)
+}
+
+func addImportNodes(parent *tview.TreeNode, imports []*claudemd.Import) {
+	for _, imp := range imports {
+		node := tview.NewTreeNode(FormatImport(imp, false)).
+			SetReference(imp).
+			SetColor(importColor(imp))
+		parent.AddChild(node)
+		addImportNodes(node, imp.Children)
+	}
+}
+
+func importColor(imp *claudemd.Import) tcell.Color {
+	switch {
+	case imp.Missing:
+		return tcell.ColorRed
+	case imp.Cycle, imp.DepthExceeded:
+		return tcell.ColorYellow
+	default:
+		return tcell.ColorWhite
+	}
+}
+
+// FormatImport renders an import with its status; tagged adds color tags
+func FormatImport(imp *claudemd.Import, tagged bool) string {
+	status := ""
+	switch {
+	case imp.Missing:
+		status = "missing"
+	case imp.Cycle:
+		status = "cycle"
+	case imp.DepthExceeded:
+		status = fmt.Sprintf("depth limit %d", claudemd.MaxImportDepth)
+	}
+	
+	text := fmt.Sprintf("%s (line %d)", imp.Raw, imp.Line)
+	if !tagged {
+		if status != "" {
+			text += " [" + status + "]"
+		}
+		return text
+	}
+	
+	text = tview.Escape(text)
+	switch {
+	case imp.Missing:
+		return "[red]" + text + " (" + status + ")[-]"
+	case status != "":
+		return "[yellow]" + text + " (" + status + ")[-]"
+	default:
+		return text
+	}
+}
+
+// FormatImportTree renders imports as an indented tree with color tags
+func FormatImportTree(imports []*claudemd.Import) string {
+	var b strings.Builder
+	writeImportTree(&b, imports, "")
+	return b.String()
+}
+
+func writeImportTree(b *strings.Builder, imports []*claudemd.Import, prefix string) {
+	for n, imp := range imports {
+		branch, next := "├─ ", "│  "
+		if n == len(imports)-1 {
+			branch, next = "└─ ", "   "
+		}
+		b.WriteString(prefix + branch + FormatImport(imp, true) + "\n")
+		writeImportTree(b, imp.Children, prefix+next)
+	}
+}
+
+func (i *ImportsComponent) onNodeSelected(node *tview.TreeNode) {
+	imp, ok := node.GetReference().(*claudemd.Import)
+	if !ok || imp.Missing || i.eventHandler == nil {
+		return
+	}
+	
+	content, err := os.ReadFile(imp.Path)
+	if err != nil {
+		return
+	}
+	
+	path := imp.Path
+	if cwd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(cwd, imp.Path); err == nil && !strings.HasPrefix(rel, "..") {
+			path = rel
+		}
+	}
+	
+	i.eventHandler(types.Event{
+		Type: types.EventFileSelected,
+		Data: types.FileEvent{
+			File:  types.FileItem{Path: path, Content: string(content)},
+			Index: -1,
+		},
+	})
+}
+
+func (i *ImportsComponent) GetPrimitive() tview.Primitive {
+	return i.frame
+}
+
+func (i *ImportsComponent) GetTree() tview.Primitive {
+	return i.tree
+}
+
+func (i *ImportsComponent) SetEventHandler(handler types.EventHandler) {
+	i.eventHandler = handler
+}
+
+func (i *ImportsComponent) Focus() {}
+
+func (i *ImportsComponent) Blur() {}
+
+func (i *ImportsComponent) Update(data interface{}) {
+	// Imports component is driven through Show
+}
@@ -1,15 +1,20 @@
 package components
 
 import (
+	"fmt"
+	"strings"
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 	"rules-explorer/internal/core/types"
+	"rules-explorer/internal/validate"
 )
 
 type PreviewComponent struct {
 	textView     *tview.TextView
 	theme        types.Theme
 	eventHandler types.EventHandler
+	matchCount   int
+	currentMatch int
 }
 
 func NewPreviewComponent(th types.Theme) *PreviewComponent {
@@ -38,12 +43,14 @@ func (p *PreviewComponent) setupTextView() {
 		SetTextStyle(transparentStyle)
 	
 	p.textView.SetBorder(true).
-		SetTitle("[white]📖 Content Preview[-]").
+		SetTitle(previewTitle).
 		SetTitleAlign(tview.AlignLeft).
 		SetBorderColor(colors.Border).
 		SetBackgroundColor(tcell.ColorDefault)
 }
 
+const previewTitle = "[white]📖 Content Preview[-]"
+
 func (p *PreviewComponent) GetPrimitive() tview.Primitive {
 	return p.textView
 }
@@ -65,17 +72,182 @@ func (p *PreviewComponent) Blur() {
 func (p *PreviewComponent) Update(data interface{}) {
 	switch v := data.(type) {
 	case types.FileItem:
-		p.SetContent(v.Content)
+		p.SetFileContent(v, nil)
 	case string:
 		p.SetContent(v)
 	}
 }
 
 func (p *PreviewComponent) SetContent(content string) {
+	p.resetMatches()
 	p.textView.Clear()
 	p.textView.SetText(content)
 }
 
+// SetFileContent shows raw file content with every span wrapped in a
+// highlight region, and scrolls to the first match. Config files with a
+// bundled schema get a gutter marking their validation problems.
+func (p *PreviewComponent) SetFileContent(file types.FileItem, spans []types.Span) {
+	p.resetMatches()
+	
+	content := file.Content
+	var b strings.Builder
+	g := newGutter(&b, validate.File(file.Path, content))
+	last := 0
+	for _, span := range spans {
+		if span.Start < last || span.End > len(content) {
+			continue
+		}
+		g.write(content[last:span.Start], "")
+		fmt.Fprintf(&b, `["m%d"][black:yellow]`, p.matchCount)
+		g.write(content[span.Start:span.End], "[black:yellow]")
+		b.WriteString(`[-:-][""]`)
+		last = span.End
+		p.matchCount++
+	}
+	g.write(content[last:], "")
+	g.finish()
+	
+	p.textView.Clear()
+	p.textView.SetText(b.String())
+	
+	if p.matchCount > 0 {
+		p.selectMatch(0)
+	} else {
+		p.textView.ScrollToBeginning()
+	}
+}
+
+// SetFileContentAt highlights spans like SetFileContent and selects the first
+// match at or after offset
+func (p *PreviewComponent) SetFileContentAt(file types.FileItem, spans []types.Span, offset int) {
+	p.SetFileContent(file, spans)
+	
+	index := 0
+	for _, span := range spans {
+		if span.End > offset {
+			break
+		}
+		index++
+	}
+	if index < p.matchCount {
+		p.selectMatch(index)
+	}
+}
+
+func (p *PreviewComponent) NextMatch() {
+	if p.matchCount > 0 {
+		p.selectMatch((p.currentMatch + 1) % p.matchCount)
+	}
+}
+
+func (p *PreviewComponent) PrevMatch() {
+	if p.matchCount > 0 {
+		p.selectMatch((p.currentMatch - 1 + p.matchCount) % p.matchCount)
+	}
+}
+
+func (p *PreviewComponent) selectMatch(index int) {
+	p.currentMatch = index
+	p.textView.Highlight(fmt.Sprintf("m%d", index)).ScrollToHighlight()
+	p.textView.SetTitle(fmt.Sprintf("%s [yellow](%d of %d)[-]", previewTitle, index+1, p.matchCount))
+}
+
+func (p *PreviewComponent) resetMatches() {
+	p.matchCount = 0
+	p.currentMatch = 0
+	p.textView.Highlight()
+	p.textView.SetTitle(previewTitle)
+}
+
 func (p *PreviewComponent) Clear() {
 	p.textView.Clear()
+}
+
+// gutter writes file content, prefixing every line with its number and a
+// marker when it has problems, and appending their messages to the line
+type gutter struct {
+	b        *strings.Builder
+	problems map[int][]validate.Problem
+	line     int
+	// pending is set at the start of a line whose prefix isn't written yet
+	pending bool
+}
+
+func newGutter(b *strings.Builder, problems []validate.Problem) *gutter {
+	g := &gutter{b: b, line: 1}
+	if len(problems) == 0 {
+		return g
+	}
+	
+	g.problems = make(map[int][]validate.Problem)
+	for _, problem := range problems {
+		g.problems[problem.Line] = append(g.problems[problem.Line], problem)
+	}
+	g.pending = true
+	return g
+}
+
+// write escapes text; style is the color tag in effect, which is closed
+// around the gutter
+func (g *gutter) write(text, style string) {
+	if g.problems == nil {
+		g.b.WriteString(tview.Escape(text))
+		return
+	}
+	
+	for text != "" {
+		if g.pending {
+			if style != "" {
+				g.b.WriteString("[-:-]")
+			}
+			g.prefix()
+			g.b.WriteString(style)
+			g.pending = false
+		}
+		
+		end := strings.IndexByte(text, '\n')
+		if end < 0 {
+			g.b.WriteString(tview.Escape(text))
+			return
+		}
+		g.b.WriteString(tview.Escape(text[:end]))
+		if style != "" {
+			g.b.WriteString("[-:-]")
+		}
+		g.notes()
+		g.b.WriteString("\n")
+		g.b.WriteString(style)
+		g.line++
+		g.pending = true
+		text = text[end+1:]
+	}
+}
+
+func (g *gutter) finish() {
+	if g.problems != nil && !g.pending {
+		g.notes()
+	}
+}
+
+func (g *gutter) prefix() {
+	marker := " "
+	for _, problem := range g.problems[g.line] {
+		if problem.Level == validate.LevelError {
+			marker = "[red]✖[-]"
+			break
+		}
+		marker = "[yellow]⚠[-]"
+	}
+	fmt.Fprintf(g.b, "[gray]%4d[-]%s ", g.line, marker)
+}
+
+func (g *gutter) notes() {
+	for _, problem := range g.problems[g.line] {
+		color := "[yellow]"
+		if problem.Level == validate.LevelError {
+			color = "[red]"
+		}
+		fmt.Fprintf(g.b, "  %s← %d: %s[-]", color, problem.Column, tview.Escape(problem.String()))
+	}
 }
\ No newline at end of file
@@ -0,0 +1,88 @@
+package components
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"rules-explorer/internal/core/types"
+)
+
+// PromptComponent is a single-line input shown as a modal over the main layout
+type PromptComponent struct {
+	input        *tview.InputField
+	frame        *tview.Flex
+	theme        types.Theme
+	eventHandler types.EventHandler
+	onDone       func(text string, accepted bool)
+}
+
+func NewPromptComponent(th types.Theme) *PromptComponent {
+	p := &PromptComponent{
+		input: tview.NewInputField(),
+		theme: th,
+	}
+	
+	p.setupInput()
+	return p
+}
+
+func (p *PromptComponent) setupInput() {
+	colors := p.theme.GetColors()
+	
+	p.input.
+		SetFieldWidth(0).
+		SetFieldBackgroundColor(tcell.ColorDefault).
+		SetFieldTextColor(colors.Text).
+		SetLabelColor(colors.Accent)
+	
+	p.input.SetBorder(true).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(colors.BorderFocus).
+		SetBackgroundColor(tcell.ColorDefault)
+	
+	p.input.SetDoneFunc(func(key tcell.Key) {
+		if p.onDone == nil {
+			return
+		}
+		done := p.onDone
+		p.onDone = nil
+		done(p.input.GetText(), key == tcell.KeyEnter)
+	})
+	
+	// Center the input horizontally and vertically
+	p.frame = tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(p.input, 3, 0, true).
+			AddItem(nil, 0, 1, false), 0, 2, true).
+		AddItem(nil, 0, 1, false)
+}
+
+// Show resets the prompt; done is called once with the entered text and
+// whether it was confirmed with Enter
+func (p *PromptComponent) Show(title, label, text string, done func(text string, accepted bool)) {
+	p.input.SetTitle("[yellow]" + title + "[-]")
+	p.input.SetLabel(label)
+	p.input.SetText(text)
+	p.onDone = done
+}
+
+func (p *PromptComponent) GetPrimitive() tview.Primitive {
+	return p.frame
+}
+
+func (p *PromptComponent) GetInput() tview.Primitive {
+	return p.input
+}
+
+func (p *PromptComponent) SetEventHandler(handler types.EventHandler) {
+	p.eventHandler = handler
+}
+
+func (p *PromptComponent) Focus() {}
+
+func (p *PromptComponent) Blur() {}
+
+func (p *PromptComponent) Update(data interface{}) {
+	// Prompt component is driven through Show
+}
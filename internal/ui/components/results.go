@@ -0,0 +1,176 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"rules-explorer/internal/core/search"
+	"rules-explorer/internal/core/types"
+)
+
+// ResultsComponent lists matching lines as path:line: snippet entries
+type ResultsComponent struct {
+	list         *tview.List
+	theme        types.Theme
+	eventHandler types.EventHandler
+	matches      []search.LineMatch
+}
+
+func NewResultsComponent(th types.Theme) *ResultsComponent {
+	r := &ResultsComponent{
+		list:    tview.NewList(),
+		theme:   th,
+		matches: make([]search.LineMatch, 0),
+	}
+	
+	r.setupList()
+	return r
+}
+
+func (r *ResultsComponent) setupList() {
+	colors := r.theme.GetColors()
+	
+	mainTextStyle := tcell.StyleDefault.
+		Background(tcell.ColorDefault).
+		Foreground(colors.Text)
+	
+	secondaryTextStyle := tcell.StyleDefault.
+		Background(tcell.ColorDefault).
+		Foreground(colors.Secondary)
+	
+	r.list.
+		ShowSecondaryText(false).
+		SetSelectedFunc(r.onLineSelected).
+		SetChangedFunc(r.onLineSelected).
+		SetMainTextStyle(mainTextStyle).
+		SetSecondaryTextStyle(secondaryTextStyle).
+		SetSelectedBackgroundColor(tcell.ColorDefault).
+		SetSelectedStyle(tcell.StyleDefault.Background(tcell.ColorDefault).Foreground(tcell.ColorYellow)).
+		SetSelectedFocusOnly(false).
+		SetHighlightFullLine(true)
+	
+	r.list.SetBorder(true).
+		SetTitle("[aqua]≡ Matching Lines[-]").
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(colors.Border).
+		SetBackgroundColor(tcell.ColorDefault)
+}
+
+func (r *ResultsComponent) onLineSelected(index int, mainText string, secondaryText string, shortcut rune) {
+	if r.eventHandler != nil && index >= 0 && index < len(r.matches) {
+		match := r.matches[index]
+		r.eventHandler(types.Event{
+			Type: types.EventLineSelected,
+			Data: types.LineEvent{
+				File:   match.File,
+				Line:   match.Line,
+				Offset: match.Offset,
+			},
+		})
+	}
+}
+
+func (r *ResultsComponent) GetPrimitive() tview.Primitive {
+	return r.list
+}
+
+func (r *ResultsComponent) SetEventHandler(handler types.EventHandler) {
+	r.eventHandler = handler
+}
+
+func (r *ResultsComponent) Focus() {
+	colors := r.theme.GetColors()
+	r.list.SetBorderColor(colors.BorderFocus)
+}
+
+func (r *ResultsComponent) Blur() {
+	colors := r.theme.GetColors()
+	r.list.SetBorderColor(colors.Border)
+}
+
+func (r *ResultsComponent) Update(data interface{}) {
+	if matches, ok := data.([]search.LineMatch); ok {
+		r.updateMatches(matches)
+	}
+}
+
+func (r *ResultsComponent) updateMatches(matches []search.LineMatch) {
+	r.matches = matches
+	r.list.Clear()
+	
+	files := make(map[string]bool)
+	for _, match := range matches {
+		files[match.File.Path] = true
+		r.list.AddItem(FormatLineMatch(match, true), "", 0, nil)
+	}
+	
+	r.list.SetTitle(fmt.Sprintf("[aqua]≡ Matching Lines[-] [gray](%d in %d files)[-]", len(matches), len(files)))
+	
+	if len(matches) > 0 {
+		r.list.SetCurrentItem(0)
+		r.onLineSelected(0, "", "", 0)
+	}
+}
+
+// FormatLineMatch renders "path:line: snippet"; tagged highlights the spans
+func FormatLineMatch(match search.LineMatch, tagged bool) string {
+	prefix := fmt.Sprintf("%s:%d: ", match.File.Path, match.Line)
+	text := strings.TrimLeft(match.Text, " \t")
+	trimmed := len(match.Text) - len(text)
+	
+	if !tagged {
+		return prefix + text
+	}
+	
+	var b strings.Builder
+	b.WriteString("[aqua]" + tview.Escape(prefix) + "[-]")
+	last := 0
+	for _, span := range match.Spans {
+		start, end := span.Start-trimmed, span.End-trimmed
+		if start < last || end > len(text) || start < 0 {
+			continue
+		}
+		b.WriteString(tview.Escape(text[last:start]))
+		b.WriteString("[black:yellow]" + tview.Escape(text[start:end]) + "[-:-]")
+		last = end
+	}
+	b.WriteString(tview.Escape(text[last:]))
+	return b.String()
+}
+
+// FormatContext renders the match with its surrounding lines, numbered
+func FormatContext(match search.LineMatch) string {
+	var b strings.Builder
+	first := match.Line - len(match.Before)
+	for i, line := range match.Before {
+		fmt.Fprintf(&b, "[gray]%4d│ %s[-]\n", first+i, tview.Escape(line))
+	}
+	fmt.Fprintf(&b, "[yellow]%4d│[-] %s\n", match.Line, tview.Escape(match.Text))
+	for i, line := range match.After {
+		fmt.Fprintf(&b, "[gray]%4d│ %s[-]\n", match.Line+1+i, tview.Escape(line))
+	}
+	return b.String()
+}
+
+func (r *ResultsComponent) GetCurrentMatch() (search.LineMatch, bool) {
+	index := r.list.GetCurrentItem()
+	if index < 0 || index >= len(r.matches) {
+		return search.LineMatch{}, false
+	}
+	return r.matches[index], true
+}
+
+func (r *ResultsComponent) NavigateUp() {
+	current := r.list.GetCurrentItem()
+	if current > 0 {
+		r.list.SetCurrentItem(current - 1)
+	}
+}
+
+func (r *ResultsComponent) NavigateDown() {
+	current := r.list.GetCurrentItem()
+	if current < r.list.GetItemCount()-1 {
+		r.list.SetCurrentItem(current + 1)
+	}
+}
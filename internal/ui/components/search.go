@@ -10,6 +10,7 @@ type SearchComponent struct {
 	input       *tview.InputField
 	theme       types.Theme
 	eventHandler types.EventHandler
+	regexMode   bool
 }
 
 func NewSearchComponent(th types.Theme) *SearchComponent {
@@ -24,17 +25,16 @@ func NewSearchComponent(th types.Theme) *SearchComponent {
 
 func (s *SearchComponent) setupInput() {
 	colors := s.theme.GetColors()
-	icons := s.theme.GetIcons()
 	
 	s.input.
 		SetFieldWidth(0).
-		SetPlaceholder("Type to filter files...").
+		SetPlaceholder("Type to filter files... (type:cursor path:src -draft \"exact phrase\" OR ...)").
 		SetFieldBackgroundColor(tcell.ColorDefault).
 		SetFieldTextColor(colors.Text).
 		SetPlaceholderStyle(tcell.StyleDefault.Background(tcell.ColorDefault).Foreground(colors.Secondary))
 	
 	s.input.SetBorder(true).
-		SetTitle("[yellow]" + icons.Search + " Search Rules & Config Files[-]").
+		SetTitle(s.defaultTitle()).
 		SetTitleAlign(tview.AlignLeft).
 		SetBorderColor(colors.Border).
 		SetBackgroundColor(tcell.ColorDefault)
@@ -42,6 +42,32 @@ func (s *SearchComponent) setupInput() {
 	s.input.SetChangedFunc(s.onSearchChanged)
 }
 
+func (s *SearchComponent) defaultTitle() string {
+	icons := s.theme.GetIcons()
+	if s.regexMode {
+		return "[yellow]" + icons.Search + " Search Rules & Config Files [aqua](regex)[-]"
+	}
+	return "[yellow]" + icons.Search + " Search Rules & Config Files[-]"
+}
+
+func (s *SearchComponent) SetRegexMode(enabled bool) {
+	s.regexMode = enabled
+	s.input.SetTitle(s.defaultTitle())
+}
+
+// SetError shows a query error in the title, or restores the title when nil
+func (s *SearchComponent) SetError(err error) {
+	if err == nil {
+		s.input.SetTitle(s.defaultTitle())
+		return
+	}
+	mode := ""
+	if s.regexMode {
+		mode = "(regex) "
+	}
+	s.input.SetTitle("[red]" + s.theme.GetIcons().Search + " " + mode + tview.Escape(err.Error()) + "[-]")
+}
+
 func (s *SearchComponent) onSearchChanged(text string) {
 	if s.eventHandler != nil {
 		event := types.Event{
@@ -0,0 +1,185 @@
+package components
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rivo/tview"
+	"rules-explorer/internal/core/settings"
+)
+
+// scopeTag renders a scope in the color used for it everywhere
+func scopeTag(scope settings.Scope) string {
+	color := "[gray]"
+	switch scope {
+	case settings.ScopeProject:
+		color = "[green]"
+	case settings.ScopeLocal:
+		color = "[aqua]"
+	}
+	return color + tview.Escape("["+scope.String()+"]") + "[-]"
+}
+
+func valueTag(v settings.Value) string {
+	tag := scopeTag(v.Scope)
+	for _, scope := range v.Overrides {
+		tag += " [gray]overrides " + scope.String() + "[-]"
+	}
+	return tag
+}
+
+func formatHook(matcher string, hook settings.Hook) string {
+	if matcher == "" {
+		matcher = "*"
+	}
+	command := hook.Command
+	if hook.Type != "" && hook.Type != "command" {
+		command = hook.Type + ": " + command
+	}
+	line := fmt.Sprintf("[white]%s[-] → %s", tview.Escape(matcher), tview.Escape(command))
+	if hook.Timeout > 0 {
+		line += fmt.Sprintf(" [gray](%ds)[-]", hook.Timeout)
+	}
+	return line
+}
+
+// FormatSettings renders one settings file as permission lists, model,
+// environment and hooks with color tags
+func FormatSettings(s *settings.Settings) string {
+	var b strings.Builder
+	
+	for _, list := range []struct {
+		name   string
+		color  string
+		values []string
+	}{
+		{"Allow", "[green]", s.Permissions.Allow},
+		{"Ask", "[yellow]", s.Permissions.Ask},
+		{"Deny", "[red]", s.Permissions.Deny},
+		{"Directories", "[white]", s.Permissions.AdditionalDirectories},
+	} {
+		if len(list.values) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "%s%s (%d):[-]\n", list.color, list.name, len(list.values))
+		for _, value := range list.values {
+			fmt.Fprintf(&b, "  %s\n", tview.Escape(value))
+		}
+	}
+	if s.Permissions.DefaultMode != "" {
+		fmt.Fprintf(&b, "[yellow]Default mode:[-] %s\n", tview.Escape(s.Permissions.DefaultMode))
+	}
+	if s.Model != "" {
+		fmt.Fprintf(&b, "[yellow]Model:[-] %s\n", tview.Escape(s.Model))
+	}
+	
+	if len(s.Env) > 0 {
+		b.WriteString("[yellow]Env:[-]\n")
+		for _, name := range sortedKeys(s.Env) {
+			fmt.Fprintf(&b, "  %s=%s\n", tview.Escape(name), tview.Escape(s.Env[name]))
+		}
+	}
+	
+	if len(s.Hooks) > 0 {
+		fmt.Fprintf(&b, "[yellow]Hooks (%d):[-]\n", s.HookCount())
+		for _, event := range s.HookEvents() {
+			fmt.Fprintf(&b, "  %s\n", tview.Escape(event))
+			for _, matcher := range s.Hooks[event] {
+				for _, hook := range matcher.Hooks {
+					fmt.Fprintf(&b, "    %s\n", formatHook(matcher.Matcher, hook))
+				}
+			}
+		}
+	}
+	
+	if b.Len() == 0 {
+		return "[gray](nothing set)[-]\n"
+	}
+	return b.String()
+}
+
+// FormatEffectiveSettings renders the merged settings with the scope each
+// value comes from
+func FormatEffectiveSettings(files []settings.File, effective settings.Effective) string {
+	var b strings.Builder
+	b.WriteString("[yellow]── Effective Claude Code settings ──[-]\n\n")
+	
+	if len(files) == 0 {
+		b.WriteString("[yellow]No settings files found[-]")
+		return b.String()
+	}
+	
+	b.WriteString("[white]Files[-] [gray](lowest precedence first)[-]\n")
+	for _, file := range files {
+		fmt.Fprintf(&b, "  %s %s", scopeTag(file.Scope), tview.Escape(file.DisplayPath))
+		if file.Err != nil {
+			fmt.Fprintf(&b, " [red]%s[-]", tview.Escape(file.Err.Error()))
+		}
+		b.WriteString("\n")
+	}
+	
+	if effective.Empty() {
+		b.WriteString("\n[gray]Nothing is set[-]")
+		return b.String()
+	}
+	
+	for _, list := range []struct {
+		name   string
+		color  string
+		values []settings.Value
+	}{
+		{"Allow", "[green]", effective.Allow},
+		{"Ask", "[yellow]", effective.Ask},
+		{"Deny", "[red]", effective.Deny},
+		{"Additional directories", "[white]", effective.Directories},
+	} {
+		if len(list.values) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n%s%s[-]\n", list.color, list.name)
+		for _, v := range list.values {
+			fmt.Fprintf(&b, "  %s %s\n", tview.Escape(v.Value), valueTag(v))
+		}
+	}
+	
+	if effective.DefaultMode.Value != "" || effective.Model.Value != "" {
+		b.WriteString("\n[white]Mode and model[-]\n")
+		if v := effective.DefaultMode; v.Value != "" {
+			fmt.Fprintf(&b, "  default mode: %s %s\n", tview.Escape(v.Value), valueTag(v))
+		}
+		if v := effective.Model; v.Value != "" {
+			fmt.Fprintf(&b, "  model: %s %s\n", tview.Escape(v.Value), valueTag(v))
+		}
+	}
+	
+	if len(effective.Env) > 0 {
+		b.WriteString("\n[white]Environment[-]\n")
+		for _, v := range effective.Env {
+			fmt.Fprintf(&b, "  %s=%s %s\n", tview.Escape(v.Name), tview.Escape(v.Value.Value), valueTag(v.Value))
+		}
+	}
+	
+	if len(effective.Hooks) > 0 {
+		b.WriteString("\n[white]Hooks[-]\n")
+		event := ""
+		for _, hook := range effective.Hooks {
+			if hook.Event != event {
+				event = hook.Event
+				fmt.Fprintf(&b, "  %s\n", tview.Escape(event))
+			}
+			fmt.Fprintf(&b, "    %s %s\n", formatHook(hook.Matcher, hook.Hook), scopeTag(hook.Scope))
+		}
+	}
+	
+	return b.String()
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -2,9 +2,12 @@ package components
 
 import (
 	"fmt"
+	"strings"
 	"time"
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
+	"rules-explorer/internal/core/resolver"
+	"rules-explorer/internal/core/tokens"
 	"rules-explorer/internal/core/types"
 	"rules-explorer/internal/ui/theme"
 )
@@ -15,12 +18,25 @@ type StatsComponent struct {
 	eventHandler types.EventHandler
 	allFiles     []types.FileItem
 	filteredFiles []types.FileItem
+	budget       tokens.Budget
+	
+	// Token counts per path, recounted only when the content changes
+	tokenCounts  map[string]tokenCount
+	alwaysFiles  int
+	alwaysTokens int
+}
+
+type tokenCount struct {
+	content string
+	tokens  int
 }
 
 func NewStatsComponent(th types.Theme) *StatsComponent {
 	s := &StatsComponent{
-		textView: tview.NewTextView(),
-		theme:    th,
+		textView:    tview.NewTextView(),
+		theme:       th,
+		budget:      tokens.DefaultBudget,
+		tokenCounts: make(map[string]tokenCount),
 	}
 	
 	s.setupTextView()
@@ -66,14 +82,53 @@ func (s *StatsComponent) Blur() {
 	s.textView.SetBorderColor(colors.Border)
 }
 
+// SetTokenBudget sets the limit for the always-applied files
+func (s *StatsComponent) SetTokenBudget(budget tokens.Budget) {
+	s.budget = budget
+	s.updateStats()
+}
+
 func (s *StatsComponent) Update(data interface{}) {
 	switch v := data.(type) {
 	case []types.FileItem:
 		s.allFiles = v
+		s.countTokens()
 		s.updateStats()
 	}
 }
 
+func (s *StatsComponent) countTokens() {
+	counts := make(map[string]tokenCount, len(s.allFiles))
+	for _, file := range s.allFiles {
+		// Comparing the content is cheap when it is the same string
+		if cached, ok := s.tokenCounts[file.Path]; ok && cached.content == file.Content {
+			counts[file.Path] = cached
+			continue
+		}
+		counts[file.Path] = tokenCount{content: file.Content, tokens: tokens.Count(file.Content)}
+	}
+	s.tokenCounts = counts
+	
+	always := resolver.AlwaysApplied("", s.allFiles)
+	s.alwaysFiles = len(always)
+	s.alwaysTokens = 0
+	for _, file := range always {
+		if cached, ok := counts[file.Path]; ok && cached.content == file.Content {
+			s.alwaysTokens += cached.tokens
+		} else {
+			s.alwaysTokens += tokens.Count(file.Content)
+		}
+	}
+}
+
+func (s *StatsComponent) sumTokens(files []types.FileItem) int {
+	total := 0
+	for _, file := range files {
+		total += s.tokenCounts[file.Path].tokens
+	}
+	return total
+}
+
 func (s *StatsComponent) SetFilteredFiles(files []types.FileItem) {
 	s.filteredFiles = files
 	s.updateStats()
@@ -84,39 +139,47 @@ func (s *StatsComponent) updateStats() {
 	filteredCount := len(s.filteredFiles)
 	
 	// Count by type
-	cursorRules := 0
-	claudeConfigs := 0
-	configFiles := 0
-	
+	counts := make(map[types.FileType]int)
+	typeTokens := make(map[types.FileType]int)
 	for _, file := range s.allFiles {
-		fileType := theme.DetermineFileType(file.Path)
-		switch fileType {
-		case types.CursorRule:
-			cursorRules++
-		case types.ClaudeConfig:
-			claudeConfigs++
-		case types.ConfigFile:
-			configFiles++
-		}
+		fileType := theme.DetermineItemType(file)
+		counts[fileType]++
+		typeTokens[fileType] += s.tokenCounts[file.Path].tokens
 	}
 	
 	icons := s.theme.GetIcons()
 	
-	stats := fmt.Sprintf(`[yellow]Total Files:[-] %d
-[yellow]Filtered:[-] %d
+	var byType strings.Builder
+	for _, fileType := range types.KnownFileTypes {
+		if counts[fileType] == 0 {
+			continue
+		}
+		fmt.Fprintf(&byType, "%s%s[-] %s: %d [gray]~%s tok[-]\n",
+			theme.GetFileTypeColor(fileType), theme.GetFileTypeIconPlain(fileType, icons), fileType.String(), counts[fileType],
+			tokens.Format(typeTokens[fileType]))
+	}
+	if counts[types.Unknown] > 0 {
+		fmt.Fprintf(&byType, "[white]%s[-] Other: %d [gray]~%s tok[-]\n", icons.File, counts[types.Unknown],
+			tokens.Format(typeTokens[types.Unknown]))
+	}
+	
+	always := fmt.Sprintf("%d files, ~%s tok", s.alwaysFiles, tokens.Format(s.alwaysTokens))
+	if s.budget.AlwaysExceeded(s.alwaysTokens) {
+		always = fmt.Sprintf("[red]%s ⚠ over the %s budget[-]", always, tokens.Format(s.budget.Always))
+	}
+	
+	stats := fmt.Sprintf(`[yellow]Total Files:[-] %d [gray]~%s tok[-]
+[yellow]Filtered:[-] %d [gray]~%s tok[-]
+[yellow]Always Applied:[-] %s
 
 [yellow]By Type:[-]
-[red]%s[-] Cursor Rules: %d
-[green]%s[-] Claude Configs: %d
-[blue]%s[-] Config Files: %d
-
+%s
 [yellow]Timestamp:[-]
 %s`,
-		totalCount,
-		filteredCount,
-		icons.CursorRule, cursorRules,
-		icons.ClaudeConfig, claudeConfigs,
-		icons.ConfigFile, configFiles,
+		totalCount, tokens.Format(s.sumTokens(s.allFiles)),
+		filteredCount, tokens.Format(s.sumTokens(s.filteredFiles)),
+		always,
+		byType.String(),
 		time.Now().Format("15:04:05"))
 	
 	s.textView.SetText(stats)
@@ -15,8 +15,14 @@ type StatusBarComponent struct {
 	currentFile   string
 	filteredCount int
 	totalCount    int
+	loading       bool
+	scanned       int
+	frame         int
+	note          string
 }
 
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
 func NewStatusBarComponent(th types.Theme) *StatusBarComponent {
 	s := &StatusBarComponent{
 		textView:    tview.NewTextView(),
@@ -83,9 +89,41 @@ func (s *StatusBarComponent) SetCounts(filtered, total int) {
 	s.updateStatus()
 }
 
+// SetLoading shows a scan in progress with the number of paths scanned
+func (s *StatusBarComponent) SetLoading(scanned int) {
+	s.loading = true
+	s.scanned = scanned
+	s.frame = (s.frame + 1) % len(spinnerFrames)
+	s.note = ""
+	s.updateStatus()
+}
+
+// SetLoaded ends the scan indicator; note (which may be empty) stays shown
+// next to the file count
+func (s *StatusBarComponent) SetLoaded(note string) {
+	s.loading = false
+	s.SetNote(note)
+}
+
+// SetNote shows a short message next to the file count
+func (s *StatusBarComponent) SetNote(note string) {
+	s.note = note
+	s.updateStatus()
+}
+
 func (s *StatusBarComponent) updateStatus() {
-	statusText := fmt.Sprintf(" [yellow]Rules Explorer[-] | Files: %d/%d | Current: [aqua]%s[-] | [white]Tab[-]: Switch Panes | [white]Esc[-]: Exit",
-		s.filteredCount, s.totalCount, s.currentFile)
+	if s.loading {
+		s.textView.SetText(fmt.Sprintf(" [yellow]Rules Explorer[-] | [aqua]%s Scanning[-] %d files found, %d paths | Current: [aqua]%s[-] | [white]Esc[-]: Cancel Scan",
+			spinnerFrames[s.frame], s.totalCount, s.scanned, s.currentFile))
+		return
+	}
+	
+	files := fmt.Sprintf("Files: %d/%d", s.filteredCount, s.totalCount)
+	if s.note != "" {
+		files += " " + s.note
+	}
+	statusText := fmt.Sprintf(" [yellow]Rules Explorer[-] | %s | Current: [aqua]%s[-] | [white]Tab[-]: Switch Panes | [white]Esc[-]: Exit",
+		files, s.currentFile)
 	
 	s.textView.SetText(statusText)
 }
\ No newline at end of file
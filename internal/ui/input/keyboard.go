@@ -4,7 +4,6 @@ import (
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 	"rules-explorer/internal/core/types"
-	"rules-explorer/internal/ui/components"
 )
 
 type KeyboardHandler struct {
@@ -12,6 +11,8 @@ type KeyboardHandler struct {
 	eventHandler types.EventHandler
 	currentFocus types.Focus
 	components   map[types.Focus]types.Component
+	modal        bool
+	loading      bool
 }
 
 func NewKeyboardHandler(app *tview.Application) *KeyboardHandler {
@@ -30,7 +31,21 @@ func (k *KeyboardHandler) RegisterComponent(focus types.Focus, component types.C
 	k.components[focus] = component
 }
 
+// SetModal routes every key except Ctrl+C to the focused modal while active
+func (k *KeyboardHandler) SetModal(active bool) {
+	k.modal = active
+}
+
+// SetLoading makes Escape cancel the running scan instead of quitting
+func (k *KeyboardHandler) SetLoading(loading bool) {
+	k.loading = loading
+}
+
 func (k *KeyboardHandler) HandleGlobalKeys(event *tcell.EventKey) *tcell.EventKey {
+	if k.modal && event.Key() != tcell.KeyCtrlC {
+		return event
+	}
+	
 	switch event.Key() {
 	case tcell.KeyTab:
 		k.switchFocus(true)
@@ -51,6 +66,15 @@ func (k *KeyboardHandler) HandleGlobalKeys(event *tcell.EventKey) *tcell.EventKe
 			return nil
 		}
 	case tcell.KeyCtrlC, tcell.KeyEscape:
+		if event.Key() == tcell.KeyEscape && k.loading {
+			if k.eventHandler != nil {
+				k.eventHandler(types.Event{
+					Type: types.EventCancelLoad,
+					Data: nil,
+				})
+			}
+			return nil
+		}
 		if k.eventHandler != nil {
 			k.eventHandler(types.Event{
 				Type: types.EventQuit,
@@ -62,6 +86,22 @@ func (k *KeyboardHandler) HandleGlobalKeys(event *tcell.EventKey) *tcell.EventKe
 	case tcell.KeyEnter:
 		k.handleEnter()
 		return nil
+	case tcell.KeyCtrlG:
+		if k.eventHandler != nil {
+			k.eventHandler(types.Event{
+				Type: types.EventToggleResults,
+				Data: nil,
+			})
+		}
+		return nil
+	case tcell.KeyCtrlR:
+		if k.eventHandler != nil {
+			k.eventHandler(types.Event{
+				Type: types.EventToggleRegex,
+				Data: nil,
+			})
+		}
+		return nil
 	}
 	
 	// Handle navigation in file list
@@ -83,6 +123,70 @@ func (k *KeyboardHandler) HandleGlobalKeys(event *tcell.EventKey) *tcell.EventKe
 					})
 				}
 				return nil
+			case 'a':
+				if k.eventHandler != nil {
+					k.eventHandler(types.Event{
+						Type: types.EventResolveRules,
+						Data: nil,
+					})
+				}
+				return nil
+			case 'c':
+				if k.eventHandler != nil {
+					k.eventHandler(types.Event{
+						Type: types.EventClaudeHierarchy,
+						Data: nil,
+					})
+				}
+				return nil
+			case 'i':
+				if k.eventHandler != nil {
+					k.eventHandler(types.Event{
+						Type: types.EventShowImports,
+						Data: nil,
+					})
+				}
+				return nil
+			case 'd':
+				if k.eventHandler != nil {
+					k.eventHandler(types.Event{
+						Type: types.EventShowDuplicates,
+						Data: nil,
+					})
+				}
+				return nil
+			case 'x':
+				if k.eventHandler != nil {
+					k.eventHandler(types.Event{
+						Type: types.EventShowConflicts,
+						Data: nil,
+					})
+				}
+				return nil
+			case 's':
+				if k.eventHandler != nil {
+					k.eventHandler(types.Event{
+						Type: types.EventShowSettings,
+						Data: nil,
+					})
+				}
+				return nil
+			case 'n':
+				if k.eventHandler != nil {
+					k.eventHandler(types.Event{
+						Type: types.EventNextMatch,
+						Data: nil,
+					})
+				}
+				return nil
+			case 'N':
+				if k.eventHandler != nil {
+					k.eventHandler(types.Event{
+						Type: types.EventPrevMatch,
+						Data: nil,
+					})
+				}
+				return nil
 			}
 		}
 	}
@@ -141,18 +245,28 @@ func (k *KeyboardHandler) handleEnter() {
 	}
 }
 
+// navigable is implemented by the list components registered for FocusFileList
+type navigable interface {
+	NavigateUp()
+	NavigateDown()
+}
+
 func (k *KeyboardHandler) handleFileListNavigation(direction int) {
 	if comp, exists := k.components[types.FocusFileList]; exists {
-		if fileList, ok := comp.(*components.FileListComponent); ok {
+		if list, ok := comp.(navigable); ok {
 			if direction > 0 {
-				fileList.NavigateDown()
+				list.NavigateDown()
 			} else {
-				fileList.NavigateUp()
+				list.NavigateUp()
 			}
 		}
 	}
 }
 
+func (k *KeyboardHandler) GetCurrentFocus() types.Focus {
+	return k.currentFocus
+}
+
 func (k *KeyboardHandler) SetCurrentFocus(focus types.Focus) {
 	k.currentFocus = focus
 	
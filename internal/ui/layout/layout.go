@@ -3,14 +3,17 @@ package layout
 import (
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
+	"rules-explorer/internal/analysis"
+	"rules-explorer/internal/core/claudemd"
 	"rules-explorer/internal/core/types"
 	"rules-explorer/internal/ui/components"
 )
 
 type Manager struct {
 	theme      types.Theme
-	root       *tview.Flex
+	root       *tview.Pages
 	mainLayout *tview.Flex
+	listPages  *tview.Pages
 	
 	// Components
 	search    *components.SearchComponent
@@ -20,6 +23,11 @@ type Manager struct {
 	stats     *components.StatsComponent
 	help      *components.HelpComponent
 	statusBar *components.StatusBarComponent
+	prompt    *components.PromptComponent
+	imports   *components.ImportsComponent
+	results   *components.ResultsComponent
+	dupes     *components.DupesComponent
+	conflicts *components.ConflictsComponent
 }
 
 func NewManager(theme types.Theme) *Manager {
@@ -41,13 +49,23 @@ func (m *Manager) createComponents() {
 	m.stats = components.NewStatsComponent(m.theme)
 	m.help = components.NewHelpComponent(m.theme)
 	m.statusBar = components.NewStatusBarComponent(m.theme)
+	m.prompt = components.NewPromptComponent(m.theme)
+	m.imports = components.NewImportsComponent(m.theme)
+	m.results = components.NewResultsComponent(m.theme)
+	m.dupes = components.NewDupesComponent(m.theme)
+	m.conflicts = components.NewConflictsComponent(m.theme)
 }
 
 func (m *Manager) setupLayout() {
+	// File list and line results share the space below the search box
+	m.listPages = tview.NewPages().
+		AddPage("files", m.fileList.GetPrimitive(), true, true).
+		AddPage("results", m.results.GetPrimitive(), true, false)
+	
 	// Left side: Search + File List
 	leftPanel := tview.NewFlex().SetDirection(tview.FlexRow).
 		AddItem(m.search.GetPrimitive(), 3, 0, false).
-		AddItem(m.fileList.GetPrimitive(), 0, 1, false)
+		AddItem(m.listPages, 0, 1, false)
 	leftPanel.SetBackgroundColor(tcell.ColorDefault)
 	
 	// Main content area: Left (Files) | Right (Preview)
@@ -70,12 +88,70 @@ func (m *Manager) setupLayout() {
 	m.mainLayout.SetBackgroundColor(tcell.ColorDefault)
 	
 	// Overall layout with status bar
-	m.root = tview.NewFlex().SetDirection(tview.FlexRow).
+	main := tview.NewFlex().SetDirection(tview.FlexRow).
 		AddItem(m.mainLayout, 0, 1, true).
 		AddItem(m.statusBar.GetPrimitive(), 1, 0, false)
+	main.SetBackgroundColor(tcell.ColorDefault)
+	
+	// Pages allow modals to be layered over the main layout
+	m.root = tview.NewPages().
+		AddPage("main", main, true, true).
+		AddPage("prompt", m.prompt.GetPrimitive(), true, false).
+		AddPage("imports", m.imports.GetPrimitive(), true, false).
+		AddPage("dupes", m.dupes.GetPrimitive(), true, false).
+		AddPage("conflicts", m.conflicts.GetPrimitive(), true, false)
 	m.root.SetBackgroundColor(tcell.ColorDefault)
 }
 
+func (m *Manager) ShowPrompt(title, label, text string, done func(text string, accepted bool)) {
+	m.prompt.Show(title, label, text, func(text string, accepted bool) {
+		m.root.HidePage("prompt")
+		done(text, accepted)
+	})
+	m.root.ShowPage("prompt")
+}
+
+// ShowResults swaps the file list for the line results list and back
+func (m *Manager) ShowResults(show bool) {
+	if show {
+		m.listPages.SwitchToPage("results")
+	} else {
+		m.listPages.SwitchToPage("files")
+	}
+}
+
+func (m *Manager) ShowImports(file types.FileItem, imports []*claudemd.Import, onClose func()) {
+	m.imports.Show(file, imports, func() {
+		m.root.HidePage("imports")
+		onClose()
+	})
+	m.root.ShowPage("imports")
+}
+
+func (m *Manager) ShowDupes(clusters []analysis.Cluster, onOpen func(paragraph analysis.Paragraph), onClose func()) {
+	closeDupes := func() {
+		m.root.HidePage("dupes")
+		onClose()
+	}
+	m.dupes.Show(clusters, func(paragraph analysis.Paragraph) {
+		closeDupes()
+		onOpen(paragraph)
+	}, closeDupes)
+	m.root.ShowPage("dupes")
+}
+
+func (m *Manager) ShowConflicts(conflicts []analysis.Conflict, onOpen func(statement analysis.Statement), onClose func()) {
+	closeConflicts := func() {
+		m.root.HidePage("conflicts")
+		onClose()
+	}
+	m.conflicts.Show(conflicts, func(statement analysis.Statement) {
+		closeConflicts()
+		onOpen(statement)
+	}, closeConflicts)
+	m.root.ShowPage("conflicts")
+}
+
 func (m *Manager) GetRoot() tview.Primitive {
 	return m.root
 }
@@ -115,4 +191,24 @@ func (m *Manager) GetHelpComponent() *components.HelpComponent {
 
 func (m *Manager) GetStatusBarComponent() *components.StatusBarComponent {
 	return m.statusBar
+}
+
+func (m *Manager) GetPromptComponent() *components.PromptComponent {
+	return m.prompt
+}
+
+func (m *Manager) GetImportsComponent() *components.ImportsComponent {
+	return m.imports
+}
+
+func (m *Manager) GetResultsComponent() *components.ResultsComponent {
+	return m.results
+}
+
+func (m *Manager) GetDupesComponent() *components.DupesComponent {
+	return m.dupes
+}
+
+func (m *Manager) GetConflictsComponent() *components.ConflictsComponent {
+	return m.conflicts
 }
\ No newline at end of file
@@ -1,22 +1,27 @@
 package theme
 
 import (
-	"path/filepath"
-	"strings"
 	"rules-explorer/internal/core/types"
 )
 
 func DetermineFileType(path string) types.FileType {
-	if strings.HasSuffix(path, ".mdc") {
-		return types.CursorRule
-	}
-	if filepath.Base(path) == "CLAUDE.md" {
-		return types.ClaudeConfig
-	}
-	if strings.HasPrefix(path, ".claude/") {
-		return types.ConfigFile
+	return types.DetectFileType(path)
+}
+
+// DetermineItemType prefers the type label assigned by the discovery pattern
+// and falls back to path-based detection.
+func DetermineItemType(file types.FileItem) types.FileType {
+	return file.Type()
+}
+
+// FileTypeName returns the display name for a file, keeping custom pattern
+// labels that don't map to a known type.
+func FileTypeName(file types.FileItem) string {
+	fileType := DetermineItemType(file)
+	if fileType == types.Unknown && file.Label != "" {
+		return file.Label
 	}
-	return types.Unknown
+	return fileType.String()
 }
 
 func GetFileIcon(path string, icons types.IconSet) string {
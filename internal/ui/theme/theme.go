@@ -28,12 +28,20 @@ func (t *DefaultTheme) GetColors() types.ColorScheme {
 
 func (t *DefaultTheme) GetIcons() types.IconSet {
 	return types.IconSet{
-		CursorRule:   "📋",
-		ClaudeConfig: "📝",
-		ConfigFile:   "📒",
-		Search:       "🔍",
-		File:         "📄",
-		Folder:       "📁",
+		CursorRule:    "📋",
+		ClaudeConfig:  "📝",
+		ConfigFile:    "📒",
+		AgentsConfig:  "🤖",
+		GeminiConfig:  "💎",
+		CopilotConfig: "🚀",
+		WindsurfRule:  "🌊",
+		ClineRule:     "📐",
+		ClaudeCommand: "💬",
+		ClaudeAgent:   "🧩",
+		ClaudeSkill:   "🎓",
+		Search:        "🔍",
+		File:          "📄",
+		Folder:        "📁",
 	}
 }
 
@@ -45,6 +53,22 @@ func GetFileTypeColor(fileType types.FileType) string {
 		return "[green]"
 	case types.ConfigFile:
 		return "[blue]"
+	case types.AgentsConfig:
+		return "[orange]"
+	case types.GeminiConfig:
+		return "[purple]"
+	case types.CopilotInstructions:
+		return "[teal]"
+	case types.WindsurfRule:
+		return "[aqua]"
+	case types.ClineRule:
+		return "[fuchsia]"
+	case types.ClaudeCommand:
+		return "[yellow]"
+	case types.ClaudeAgent:
+		return "[lime]"
+	case types.ClaudeSkill:
+		return "[pink]"
 	default:
 		return "[white]"
 	}
@@ -52,16 +76,7 @@ func GetFileTypeColor(fileType types.FileType) string {
 
 func GetFileTypeIcon(fileType types.FileType, icons types.IconSet) string {
 	color := GetFileTypeColor(fileType)
-	switch fileType {
-	case types.CursorRule:
-		return color + ":-]" + icons.CursorRule + "[:-]"
-	case types.ClaudeConfig:
-		return color + ":-]" + icons.ClaudeConfig + "[:-]"
-	case types.ConfigFile:
-		return color + ":-]" + icons.ConfigFile + "[:-]"
-	default:
-		return color + ":-]" + icons.File + "[:-]"
-	}
+	return color + ":-]" + GetFileTypeIconPlain(fileType, icons) + "[:-]"
 }
 
 func GetFileTypeIconPlain(fileType types.FileType, icons types.IconSet) string {
@@ -72,6 +87,22 @@ func GetFileTypeIconPlain(fileType types.FileType, icons types.IconSet) string {
 		return icons.ClaudeConfig
 	case types.ConfigFile:
 		return icons.ConfigFile
+	case types.AgentsConfig:
+		return icons.AgentsConfig
+	case types.GeminiConfig:
+		return icons.GeminiConfig
+	case types.CopilotInstructions:
+		return icons.CopilotConfig
+	case types.WindsurfRule:
+		return icons.WindsurfRule
+	case types.ClineRule:
+		return icons.ClineRule
+	case types.ClaudeCommand:
+		return icons.ClaudeCommand
+	case types.ClaudeAgent:
+		return icons.ClaudeAgent
+	case types.ClaudeSkill:
+		return icons.ClaudeSkill
 	default:
 		return icons.File
 	}
@@ -0,0 +1,63 @@
+package validate
+
+import (
+	"errors"
+
+	"rules-explorer/internal/core/settings"
+)
+
+// formats are checks the schema language can't express, named by a
+// schema's "format"
+var formats = map[string]func(v *validator, n *node, pointer string){
+	"permission-rule": checkPermissionRule,
+	"hook":            checkHook,
+	"mcp-server":      checkMCPServer,
+}
+
+func checkPermissionRule(v *validator, n *node, pointer string) {
+	_, err := settings.ParseRule(n.str)
+	switch {
+	case err == nil:
+	case errors.Is(err, settings.ErrUnknownTool):
+		v.report(LevelWarning, n.offset, pointer, "unknown tool in permission rule %q", n.str)
+	default:
+		v.report(LevelError, n.offset, pointer, "invalid permission rule %q: %v", n.str, err)
+	}
+}
+
+// checkHook requires the field that goes with the hook's type
+func checkHook(v *validator, n *node, pointer string) {
+	kind := n.get("type")
+	if kind == nil || kind.kind != kindString {
+		return
+	}
+	switch kind.str {
+	case "command":
+		if command := n.get("command"); command == nil || (command.kind == kindString && command.str == "") {
+			v.report(LevelError, n.offset, pointer, "command hook has no command")
+		}
+	case "prompt":
+		if prompt := n.get("prompt"); prompt == nil || (prompt.kind == kindString && prompt.str == "") {
+			v.report(LevelError, n.offset, pointer, "prompt hook has no prompt")
+		}
+	}
+}
+
+// checkMCPServer requires a command for stdio servers and a URL for remote
+// ones
+func checkMCPServer(v *validator, n *node, pointer string) {
+	transport := "stdio"
+	if kind := n.get("type"); kind != nil && kind.kind == kindString {
+		transport = kind.str
+	}
+	switch transport {
+	case "stdio":
+		if n.get("command") == nil {
+			v.report(LevelError, n.offset, pointer, "stdio server has no command")
+		}
+	case "sse", "http":
+		if n.get("url") == nil {
+			v.report(LevelError, n.offset, pointer, "%s server has no url", transport)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package validate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type kind int
+
+const (
+	kindNull kind = iota
+	kindBool
+	kindNumber
+	kindString
+	kindArray
+	kindObject
+)
+
+func (k kind) String() string {
+	switch k {
+	case kindBool:
+		return "boolean"
+	case kindNumber:
+		return "number"
+	case kindString:
+		return "string"
+	case kindArray:
+		return "array"
+	case kindObject:
+		return "object"
+	default:
+		return "null"
+	}
+}
+
+// node is a parsed JSON value that remembers where it starts
+type node struct {
+	kind   kind
+	offset int
+	str    string
+	num    float64
+	fields []field
+	items  []*node
+}
+
+type field struct {
+	key    string
+	offset int
+	value  *node
+}
+
+// get returns the value of key in an object
+func (n *node) get(key string) *node {
+	for i := len(n.fields) - 1; i >= 0; i-- {
+		if n.fields[i].key == key {
+			return n.fields[i].value
+		}
+	}
+	return nil
+}
+
+// parser reads tokens with encoding/json and recovers the start of each
+// token from the decoder's offset, which points just past the previous one
+type parser struct {
+	data    string
+	decoder *json.Decoder
+}
+
+// SyntaxError is invalid JSON at a byte offset
+type SyntaxError struct {
+	Offset  int
+	Message string
+}
+
+func (e *SyntaxError) Error() string {
+	return e.Message
+}
+
+func parse(data string) (*node, error) {
+	p := &parser{data: data, decoder: json.NewDecoder(strings.NewReader(data))}
+	p.decoder.UseNumber()
+
+	root, err := p.value()
+	if err != nil {
+		return nil, p.syntaxError(err)
+	}
+	if _, err := p.decoder.Token(); err != io.EOF {
+		return nil, &SyntaxError{Offset: p.start(), Message: "unexpected data after the top-level value"}
+	}
+	return root, nil
+}
+
+// start skips the whitespace and separators before the next token
+func (p *parser) start() int {
+	offset := int(p.decoder.InputOffset())
+	for offset < len(p.data) && strings.IndexByte(" \t\r\n,:", p.data[offset]) >= 0 {
+		offset++
+	}
+	return offset
+}
+
+func (p *parser) value() (*node, error) {
+	offset := p.start()
+	token, err := p.decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	n := &node{offset: offset}
+	switch t := token.(type) {
+	case json.Delim:
+		switch t {
+		case '{':
+			n.kind = kindObject
+			for p.decoder.More() {
+				keyOffset := p.start()
+				key, err := p.decoder.Token()
+				if err != nil {
+					return nil, err
+				}
+				value, err := p.value()
+				if err != nil {
+					return nil, err
+				}
+				n.fields = append(n.fields, field{key: key.(string), offset: keyOffset, value: value})
+			}
+		case '[':
+			n.kind = kindArray
+			for p.decoder.More() {
+				item, err := p.value()
+				if err != nil {
+					return nil, err
+				}
+				n.items = append(n.items, item)
+			}
+		}
+		// The closing delimiter
+		if _, err := p.decoder.Token(); err != nil {
+			return nil, err
+		}
+	case string:
+		n.kind = kindString
+		n.str = t
+	case json.Number:
+		n.kind = kindNumber
+		n.str = t.String()
+		n.num, _ = t.Float64()
+	case bool:
+		n.kind = kindBool
+		n.str = fmt.Sprint(t)
+	case nil:
+		n.kind = kindNull
+	}
+	return n, nil
+}
+
+func (p *parser) syntaxError(err error) error {
+	var syntax *json.SyntaxError
+	if errors.As(err, &syntax) {
+		// Offset counts the byte that made the input invalid
+		return &SyntaxError{Offset: max(int(syntax.Offset)-1, 0), Message: syntax.Error()}
+	}
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return &SyntaxError{Offset: len(p.data), Message: "unexpected end of JSON input"}
+	}
+	return &SyntaxError{Offset: p.start(), Message: err.Error()}
+}
@@ -0,0 +1,291 @@
+// Package validate checks Claude Code settings and MCP config files against
+// bundled schemas. Problems carry the line and column of the offending key
+// or value.
+package validate
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"rules-explorer/internal/core/settings"
+)
+
+type Level int
+
+const (
+	LevelWarning Level = iota
+	LevelError
+)
+
+// Problem is a schema violation. Line and Column are 1-based; Column counts
+// characters.
+type Problem struct {
+	Level   Level
+	Offset  int
+	Line    int
+	Column  int
+	Pointer string
+	Message string
+}
+
+func (p Problem) String() string {
+	if p.Pointer == "" {
+		return p.Message
+	}
+	return p.Pointer + ": " + p.Message
+}
+
+// Schema is the subset of JSON Schema the bundled schemas use, plus formats
+// implemented in Go
+type Schema struct {
+	Type                 string             `json:"type"`
+	Description          string             `json:"description"`
+	Properties           map[string]*Schema `json:"properties"`
+	AdditionalProperties *Additional        `json:"additionalProperties"`
+	Items                *Schema            `json:"items"`
+	Enum                 []string           `json:"enum"`
+	Required             []string           `json:"required"`
+	Minimum              *float64           `json:"minimum"`
+	Format               string             `json:"format"`
+	// Ref names a schema in the root's definitions
+	Ref         string             `json:"$ref"`
+	Definitions map[string]*Schema `json:"definitions"`
+}
+
+// Additional is additionalProperties: false, or a schema for the values of
+// keys not listed in properties
+type Additional struct {
+	Allowed bool
+	Schema  *Schema
+}
+
+func (a *Additional) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &a.Allowed); err == nil {
+		return nil
+	}
+	a.Allowed = true
+	return json.Unmarshal(data, &a.Schema)
+}
+
+//go:embed schemas/*.json
+var bundled embed.FS
+
+var schemas = map[string]*Schema{}
+
+func init() {
+	entries, err := bundled.ReadDir("schemas")
+	if err != nil {
+		panic(err)
+	}
+	for _, entry := range entries {
+		data, err := bundled.ReadFile("schemas/" + entry.Name())
+		if err != nil {
+			panic(err)
+		}
+		schema := &Schema{}
+		if err := json.Unmarshal(data, schema); err != nil {
+			panic(fmt.Sprintf("validate: schemas/%s: %v", entry.Name(), err))
+		}
+		resolve(schema, schema.Definitions, make(map[*Schema]bool))
+		schemas[strings.TrimSuffix(entry.Name(), ".json")] = schema
+	}
+}
+
+// resolve replaces every $ref with the definition it names
+func resolve(schema *Schema, definitions map[string]*Schema, seen map[*Schema]bool) {
+	if schema == nil || seen[schema] {
+		return
+	}
+	seen[schema] = true
+
+	deref := func(s *Schema) *Schema {
+		if s == nil || s.Ref == "" {
+			return s
+		}
+		definition, ok := definitions[s.Ref]
+		if !ok {
+			panic("validate: undefined $ref " + s.Ref)
+		}
+		return definition
+	}
+
+	for key, property := range schema.Properties {
+		schema.Properties[key] = deref(property)
+		resolve(schema.Properties[key], definitions, seen)
+	}
+	if additional := schema.AdditionalProperties; additional != nil {
+		additional.Schema = deref(additional.Schema)
+		resolve(additional.Schema, definitions, seen)
+	}
+	schema.Items = deref(schema.Items)
+	resolve(schema.Items, definitions, seen)
+	for _, definition := range definitions {
+		resolve(definition, definitions, seen)
+	}
+}
+
+// SchemaFor names the bundled schema a file is checked against
+func SchemaFor(filePath string) (string, bool) {
+	if _, ok := settings.ScopeOf(filePath); ok {
+		return "settings", true
+	}
+	if path.Base(strings.ReplaceAll(filePath, "\\", "/")) == ".mcp.json" {
+		return "mcp", true
+	}
+	return "", false
+}
+
+// File validates content when filePath has a bundled schema, and returns nil
+// otherwise. Problems are sorted by position.
+func File(filePath, content string) []Problem {
+	name, ok := SchemaFor(filePath)
+	if !ok {
+		return nil
+	}
+	return Content(schemas[name], content)
+}
+
+// Content validates JSON content against a schema. Empty content sets
+// nothing and is valid.
+func Content(schema *Schema, content string) []Problem {
+	v := &validator{content: content}
+	if strings.TrimSpace(content) == "" {
+		return nil
+	}
+
+	root, err := parse(content)
+	if err != nil {
+		offset := 0
+		if syntax, ok := err.(*SyntaxError); ok {
+			offset = syntax.Offset
+		}
+		v.report(LevelError, offset, "", "invalid JSON: %v", err)
+	} else {
+		v.validate(schema, root, "")
+	}
+
+	sort.SliceStable(v.problems, func(i, j int) bool { return v.problems[i].Offset < v.problems[j].Offset })
+	return v.problems
+}
+
+type validator struct {
+	content  string
+	problems []Problem
+}
+
+func (v *validator) report(level Level, offset int, pointer, format string, args ...interface{}) {
+	line, column := position(v.content, offset)
+	v.problems = append(v.problems, Problem{
+		Level:   level,
+		Offset:  offset,
+		Line:    line,
+		Column:  column,
+		Pointer: pointer,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (v *validator) validate(schema *Schema, n *node, pointer string) {
+	if schema == nil {
+		return
+	}
+	if !typeMatches(schema.Type, n) {
+		v.report(LevelError, n.offset, pointer, "expected %s, got %s", article(schema.Type), article(n.kind.String()))
+		return
+	}
+
+	if len(schema.Enum) > 0 && n.kind == kindString && !contains(schema.Enum, n.str) {
+		v.report(LevelError, n.offset, pointer, "%q is not one of %s", n.str, strings.Join(schema.Enum, ", "))
+	}
+	if schema.Minimum != nil && n.kind == kindNumber && n.num < *schema.Minimum {
+		v.report(LevelError, n.offset, pointer, "must be at least %v", *schema.Minimum)
+	}
+
+	switch n.kind {
+	case kindObject:
+		seen := make(map[string]bool)
+		for _, f := range n.fields {
+			child := pointer + "." + f.key
+			if pointer == "" {
+				child = f.key
+			}
+			if seen[f.key] {
+				v.report(LevelWarning, f.offset, child, "duplicate key; only the last value is used")
+			}
+			seen[f.key] = true
+
+			if property, ok := schema.Properties[f.key]; ok {
+				v.validate(property, f.value, child)
+				continue
+			}
+			switch additional := schema.AdditionalProperties; {
+			case additional == nil:
+			case !additional.Allowed:
+				v.report(LevelWarning, f.offset, child, "unknown key %q", f.key)
+			default:
+				v.validate(additional.Schema, f.value, child)
+			}
+		}
+		for _, key := range schema.Required {
+			if !seen[key] {
+				v.report(LevelError, n.offset, pointer, "missing required key %q", key)
+			}
+		}
+	case kindArray:
+		for i, item := range n.items {
+			v.validate(schema.Items, item, fmt.Sprintf("%s[%d]", pointer, i))
+		}
+	}
+
+	if schema.Format != "" {
+		check, ok := formats[schema.Format]
+		if !ok {
+			panic("validate: unknown format " + schema.Format)
+		}
+		check(v, n, pointer)
+	}
+}
+
+func typeMatches(want string, n *node) bool {
+	switch want {
+	case "":
+		return true
+	case "integer":
+		return n.kind == kindNumber && !strings.ContainsAny(n.str, ".eE")
+	default:
+		return want == n.kind.String()
+	}
+}
+
+func article(kind string) string {
+	switch kind {
+	case "array", "object", "integer":
+		return "an " + kind
+	case "null":
+		return kind
+	}
+	return "a " + kind
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// position converts a byte offset to a 1-based line and column
+func position(content string, offset int) (int, int) {
+	offset = min(offset, len(content))
+	before := content[:offset]
+	line := strings.Count(before, "\n") + 1
+	lineStart := strings.LastIndexByte(before, '\n') + 1
+	return line, utf8.RuneCountInString(before[lineStart:]) + 1
+}
@@ -0,0 +1,100 @@
+package validate
+
+import (
+	"strings"
+	"testing"
+)
+
+type wantProblem struct {
+	line, column int
+	level        Level
+	// message is a substring of the problem's text
+	message string
+}
+
+func TestFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		content string
+		want    []wantProblem
+	}{
+		{
+			name:    "valid settings",
+			path:    ".claude/settings.json",
+			content: `{"permissions": {"allow": ["Bash(npm run test:*)"]}, "model": "sonnet"}`,
+		},
+		{
+			name:    "empty",
+			path:    ".claude/settings.json",
+			content: "  \n",
+		},
+		{
+			name:    "syntax error",
+			path:    ".claude/settings.json",
+			content: "{\n  \"model\": \"sonnet\",\n}",
+			want:    []wantProblem{{2, 20, LevelError, "invalid JSON"}},
+		},
+		{
+			name:    "wrong type",
+			path:    ".claude/settings.local.json",
+			content: "{\n  \"permissions\": {\n    \"allow\": \"Bash\"\n  }\n}",
+			want:    []wantProblem{{3, 14, LevelError, "permissions.allow: expected an array"}},
+		},
+		{
+			name:    "bad rule and unknown tool",
+			path:    ".claude/settings.json",
+			content: `{"permissions": {"deny": ["Bash(npm:* x)", "Frobnicate"]}}`,
+			want: []wantProblem{
+				{1, 27, LevelError, ":* is only allowed at the end"},
+				{1, 44, LevelWarning, "unknown tool"},
+			},
+		},
+		{
+			name:    "unknown and duplicate keys",
+			path:    ".claude/settings.json",
+			content: "{\n  \"modle\": \"x\",\n  \"model\": \"a\",\n  \"model\": \"b\"\n}",
+			want: []wantProblem{
+				{2, 3, LevelWarning, `unknown key "modle"`},
+				{4, 3, LevelWarning, "duplicate key"},
+			},
+		},
+		{
+			name:    "hook without command",
+			path:    ".claude/settings.json",
+			content: `{"hooks": {"PreToolUse": [{"matcher": "Bash", "hooks": [{"type": "command"}]}]}}`,
+			want:    []wantProblem{{1, 57, LevelError, "command hook has no command"}},
+		},
+		{
+			name:    "mcp servers",
+			path:    ".mcp.json",
+			content: "{\"mcpServers\": {\n  \"a\": {\"command\": \"npx\"},\n  \"b\": {\"type\": \"http\"},\n  \"c\": {}\n}}",
+			want: []wantProblem{
+				{3, 8, LevelError, "http server has no url"},
+				{4, 8, LevelError, "stdio server has no command"},
+			},
+		},
+		{
+			name:    "no schema",
+			path:    "CLAUDE.md",
+			content: "{",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problems := File(tt.path, tt.content)
+			if len(problems) != len(tt.want) {
+				t.Fatalf("got %d problems, want %d: %+v", len(problems), len(tt.want), problems)
+			}
+			for i, want := range tt.want {
+				got := problems[i]
+				if got.Line != want.line || got.Column != want.column || got.Level != want.level ||
+					!strings.Contains(got.String(), want.message) {
+					t.Errorf("problem %d = %d:%d level %d %q, want %d:%d level %d containing %q",
+						i, got.Line, got.Column, got.Level, got, want.line, want.column, want.level, want.message)
+				}
+			}
+		})
+	}
+}
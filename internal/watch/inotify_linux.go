@@ -0,0 +1,224 @@
+//go:build linux
+
+package watch
+
+import (
+	"encoding/binary"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+)
+
+const inotifyMask = syscall.IN_CREATE | syscall.IN_DELETE | syscall.IN_MODIFY |
+	syscall.IN_CLOSE_WRITE | syscall.IN_MOVED_FROM | syscall.IN_MOVED_TO |
+	syscall.IN_DELETE_SELF | syscall.IN_ONLYDIR
+
+// inotifyWatcher keeps one inotify watch per directory in the tree
+type inotifyWatcher struct {
+	root      string
+	opts      Options
+	file      *os.File
+	fd        int
+	events    chan Event
+	done      chan struct{}
+	closeOnce sync.Once
+
+	// dirs maps watch descriptors to directories relative to root; only
+	// the reading goroutine touches it once the watcher is running
+	dirs map[int32]string
+}
+
+func newNative(root string, opts Options) (Watcher, error) {
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC | syscall.IN_NONBLOCK)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &inotifyWatcher{
+		root:   root,
+		opts:   opts,
+		file:   os.NewFile(uintptr(fd), "inotify"),
+		fd:     fd,
+		events: make(chan Event, 64),
+		done:   make(chan struct{}),
+		dirs:   make(map[int32]string),
+	}
+
+	if _, err := w.addTree("."); err != nil {
+		w.file.Close()
+		return nil, err
+	}
+
+	go w.run()
+	return w, nil
+}
+
+func (w *inotifyWatcher) Events() <-chan Event {
+	return w.events
+}
+
+func (w *inotifyWatcher) Close() error {
+	var err error
+	w.closeOnce.Do(func() {
+		close(w.done)
+		err = w.file.Close()
+	})
+	return err
+}
+
+// addTree watches dir and every directory below it that isn't skipped, and
+// returns the files it found
+func (w *inotifyWatcher) addTree(dir string) ([]string, error) {
+	files := make([]string, 0)
+	err := filepath.WalkDir(filepath.Join(w.root, dir), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		relPath, err := filepath.Rel(w.root, path)
+		if err != nil {
+			return nil
+		}
+
+		if relPath != "." && w.opts.Skip(relPath, d.IsDir()) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.IsDir() {
+			files = append(files, relPath)
+			return nil
+		}
+
+		wd, err := syscall.InotifyAddWatch(w.fd, path, inotifyMask)
+		if err != nil {
+			// Running out of watches means the tree can't be covered;
+			// other errors are usually a directory that just vanished
+			if errors.Is(err, syscall.ENOSPC) {
+				return err
+			}
+			return nil
+		}
+		w.dirs[int32(wd)] = relPath
+		return nil
+	})
+	return files, err
+}
+
+func (w *inotifyWatcher) removeTree(dir string) {
+	prefix := dir + string(filepath.Separator)
+	for wd, watched := range w.dirs {
+		if watched == dir || strings.HasPrefix(watched, prefix) {
+			syscall.InotifyRmWatch(w.fd, uint32(wd))
+			delete(w.dirs, wd)
+		}
+	}
+}
+
+func (w *inotifyWatcher) run() {
+	defer close(w.events)
+	buf := make([]byte, 64*(syscall.SizeofInotifyEvent+syscall.NAME_MAX+1))
+
+	for {
+		n, err := w.file.Read(buf)
+		if err != nil {
+			if errors.Is(err, syscall.EINTR) {
+				continue
+			}
+			return
+		}
+		for _, event := range w.parse(buf[:n]) {
+			select {
+			case w.events <- event:
+			case <-w.done:
+				return
+			}
+		}
+	}
+}
+
+// parse decodes a read's worth of inotify events. Moves within the tree are
+// paired by cookie into a single Rename.
+func (w *inotifyWatcher) parse(buf []byte) []Event {
+	events := make([]Event, 0)
+	movedFrom := make(map[uint32]int)
+
+	for offset := 0; offset+syscall.SizeofInotifyEvent <= len(buf); {
+		wd := int32(binary.NativeEndian.Uint32(buf[offset:]))
+		mask := binary.NativeEndian.Uint32(buf[offset+4:])
+		cookie := binary.NativeEndian.Uint32(buf[offset+8:])
+		nameLen := int(binary.NativeEndian.Uint32(buf[offset+12:]))
+		nameStart := offset + syscall.SizeofInotifyEvent
+		offset = nameStart + nameLen
+		if offset > len(buf) {
+			break
+		}
+
+		if mask&syscall.IN_Q_OVERFLOW != 0 {
+			events = append(events, Event{Op: Rescan})
+			continue
+		}
+
+		dir, ok := w.dirs[wd]
+		if !ok {
+			continue
+		}
+		if mask&(syscall.IN_DELETE_SELF|syscall.IN_IGNORED) != 0 {
+			delete(w.dirs, wd)
+			continue
+		}
+
+		name := string(buf[nameStart:offset])
+		for len(name) > 0 && name[len(name)-1] == 0 {
+			name = name[:len(name)-1]
+		}
+		if name == "" {
+			continue
+		}
+		relPath := filepath.Join(dir, name)
+		isDir := mask&syscall.IN_ISDIR != 0
+		if w.opts.Skip(relPath, isDir) {
+			// A moved-away path may have been watched even if its new
+			// name is skipped
+			if mask&syscall.IN_MOVED_FROM != 0 {
+				events = append(events, Event{Op: Remove, Path: relPath})
+			}
+			continue
+		}
+
+		switch {
+		case isDir && mask&(syscall.IN_CREATE|syscall.IN_MOVED_TO) != 0:
+			// Files may have landed in the directory before it was watched
+			files, _ := w.addTree(relPath)
+			for _, file := range files {
+				events = append(events, Event{Op: Create, Path: file})
+			}
+		case mask&syscall.IN_MOVED_FROM != 0:
+			if isDir {
+				// Watches follow the directory; they are re-added under the
+				// new name if it is moved within the tree
+				w.removeTree(relPath)
+			}
+			movedFrom[cookie] = len(events)
+			events = append(events, Event{Op: Remove, Path: relPath})
+		case mask&syscall.IN_MOVED_TO != 0:
+			if i, ok := movedFrom[cookie]; ok && !isDir {
+				events[i] = Event{Op: Rename, Path: relPath, OldPath: events[i].Path}
+				delete(movedFrom, cookie)
+				continue
+			}
+			events = append(events, Event{Op: Create, Path: relPath})
+		case mask&syscall.IN_CREATE != 0:
+			events = append(events, Event{Op: Create, Path: relPath})
+		case mask&syscall.IN_DELETE != 0:
+			events = append(events, Event{Op: Remove, Path: relPath})
+		case mask&(syscall.IN_MODIFY|syscall.IN_CLOSE_WRITE) != 0:
+			events = append(events, Event{Op: Write, Path: relPath})
+		}
+	}
+	return events
+}
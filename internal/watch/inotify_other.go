@@ -0,0 +1,9 @@
+//go:build !linux
+
+package watch
+
+import "errors"
+
+func newNative(root string, opts Options) (Watcher, error) {
+	return nil, errors.New("native watching is not supported on this platform")
+}
@@ -0,0 +1,157 @@
+package watch
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// poller rescans the tree on an interval and diffs file sizes and
+// modification times against the previous scan
+type poller struct {
+	root      string
+	opts      Options
+	events    chan Event
+	done      chan struct{}
+	closeOnce sync.Once
+	files     map[string]fileState
+}
+
+type fileState struct {
+	size    int64
+	modTime int64
+}
+
+func newPoller(root string, opts Options) (*poller, error) {
+	files, err := scan(root, opts.Skip)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &poller{
+		root:   root,
+		opts:   opts,
+		events: make(chan Event, 64),
+		done:   make(chan struct{}),
+		files:  files,
+	}
+	go p.run()
+	return p, nil
+}
+
+func (p *poller) Events() <-chan Event {
+	return p.events
+}
+
+func (p *poller) Close() error {
+	p.closeOnce.Do(func() { close(p.done) })
+	return nil
+}
+
+func (p *poller) run() {
+	defer close(p.events)
+	ticker := time.NewTicker(p.opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-ticker.C:
+		}
+
+		files, err := scan(p.root, p.opts.Skip)
+		if err != nil {
+			continue
+		}
+		for _, event := range diff(p.files, files) {
+			select {
+			case p.events <- event:
+			case <-p.done:
+				return
+			}
+		}
+		p.files = files
+	}
+}
+
+func scan(root string, skip func(string, bool) bool) (map[string]fileState, error) {
+	if _, err := os.Stat(root); err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]fileState)
+	filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		relPath, err := filepath.Rel(root, path)
+		if err != nil || relPath == "." {
+			return nil
+		}
+
+		if skip(relPath, d.IsDir()) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		if info, err := d.Info(); err == nil {
+			files[relPath] = fileState{size: info.Size(), modTime: info.ModTime().UnixNano()}
+		}
+		return nil
+	})
+	return files, nil
+}
+
+// diff turns two scans into events. A file that disappeared and one that
+// appeared with the same size and modification time are reported as a
+// rename when the pairing is unambiguous.
+func diff(before, after map[string]fileState) []Event {
+	events := make([]Event, 0)
+	removed := make(map[fileState][]string)
+	for path, state := range before {
+		if _, ok := after[path]; !ok {
+			removed[state] = append(removed[state], path)
+		}
+	}
+
+	created := make([]string, 0)
+	for path, state := range after {
+		old, ok := before[path]
+		switch {
+		case !ok:
+			created = append(created, path)
+		case old != state:
+			events = append(events, Event{Op: Write, Path: path})
+		}
+	}
+
+	appeared := make(map[fileState]int)
+	for _, path := range created {
+		appeared[after[path]]++
+	}
+
+	for _, path := range created {
+		state := after[path]
+		if candidates := removed[state]; len(candidates) == 1 && appeared[state] == 1 {
+			events = append(events, Event{Op: Rename, Path: path, OldPath: candidates[0]})
+			delete(removed, state)
+			continue
+		}
+		events = append(events, Event{Op: Create, Path: path})
+	}
+
+	for _, paths := range removed {
+		for _, path := range paths {
+			events = append(events, Event{Op: Remove, Path: path})
+		}
+	}
+	return events
+}
@@ -0,0 +1,81 @@
+// Package watch reports changes to files under a directory tree. It uses
+// inotify on Linux and falls back to polling elsewhere, or when inotify is
+// unavailable (for example when the watch limit is exhausted).
+package watch
+
+import (
+	"time"
+)
+
+type Op int
+
+const (
+	Create Op = iota
+	Write
+	Remove
+	// Rename moves OldPath to Path
+	Rename
+	// Rescan means events were lost and the tree should be re-read
+	Rescan
+)
+
+func (op Op) String() string {
+	switch op {
+	case Create:
+		return "create"
+	case Write:
+		return "write"
+	case Remove:
+		return "remove"
+	case Rename:
+		return "rename"
+	case Rescan:
+		return "rescan"
+	default:
+		return "unknown"
+	}
+}
+
+// Event is a change to a file or directory. Paths are relative to the
+// watched root. A Remove may name a directory, in which case everything
+// below it is gone too.
+type Event struct {
+	Op      Op
+	Path    string
+	OldPath string
+}
+
+type Watcher interface {
+	Events() <-chan Event
+	Close() error
+}
+
+type Options struct {
+	// Skip reports whether a path should be ignored. Skipped directories
+	// are not descended into.
+	Skip func(relPath string, isDir bool) bool
+	// PollInterval is how often the polling watcher rescans; it defaults
+	// to DefaultPollInterval
+	PollInterval time.Duration
+	// Poll forces the polling watcher
+	Poll bool
+}
+
+const DefaultPollInterval = 2 * time.Second
+
+// New watches root. It only fails if root can't be read.
+func New(root string, opts Options) (Watcher, error) {
+	if opts.Skip == nil {
+		opts.Skip = func(string, bool) bool { return false }
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = DefaultPollInterval
+	}
+
+	if !opts.Poll {
+		if w, err := newNative(root, opts); err == nil {
+			return w, nil
+		}
+	}
+	return newPoller(root, opts)
+}